@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// minAPIKeyLength is the shortest API key accepted, shorter keys are too easy
+// to guess
+const minAPIKeyLength = 16
+
+// APIKey is a static key which grants the scopes to the caller using it
+type APIKey struct {
+	// Name identifies the caller in the logs
+	Name string `json:"name"`
+
+	// Key sent in the X-API-Key header
+	Key string `json:"key"`
+
+	// Scopes granted to the caller
+	Scopes []string `json:"scopes"`
+}
+
+// LoadAPIKeys reads the API keys from a JSON file containing an array of keys
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read API keys file: %w", err)
+	}
+
+	keys := []APIKey{}
+	err = json.Unmarshal(b, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse API keys file %s: %w", path, err)
+	}
+
+	for i, k := range keys {
+		if k.Name == "" || len(k.Key) < minAPIKeyLength {
+			return nil, fmt.Errorf("Invalid API key %d in %s, a name and a key of at least %d characters are required", i, path, minAPIKeyLength)
+		}
+	}
+
+	return keys, nil
+}
+
+// hashKey returns the SHA-256 hash of the key, keys are looked up by their hash
+// so the time taken does not depend on how much of a key matches
+func hashKey(k string) string {
+	h := sha256.Sum256([]byte(k))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,193 @@
+// Package auth authenticates requests with a JWT bearer token or a static API
+// key and authorizes them with the scopes granted to the caller.
+//
+// JWTs must be signed with HS256 or RS256 using a key from a JSON Web Key Set,
+// their scopes are read from the space separated scope claim. API keys are sent
+// in the X-API-Key header and have a fixed set of scopes
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+)
+
+// HeaderAPIKey is the header API keys are sent in
+const HeaderAPIKey = "X-API-Key"
+
+// ErrMissingCredentials is an error raised when a request has neither a bearer
+// token nor an API key
+var ErrMissingCredentials = fmt.Errorf("Missing credentials, expected a bearer token or an X-API-Key header")
+
+// ErrInvalidAPIKey is an error raised when the API key is not known
+var ErrInvalidAPIKey = fmt.Errorf("Invalid API key")
+
+// ErrInvalidToken is an error raised when the bearer token can not be verified
+var ErrInvalidToken = fmt.Errorf("Invalid bearer token")
+
+// ErrInsufficientScope is an error raised when the caller has not been granted
+// the scope required by the route
+var ErrInsufficientScope = fmt.Errorf("Insufficient scope")
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	// Subject of the JWT or the name of the API key
+	Subject string
+
+	// Scopes granted to the caller
+	Scopes []string
+}
+
+// HasScope returns true when the caller has been granted the scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type keyPrincipal struct{}
+
+// FromContext returns the caller of the request the context belongs to, false
+// is returned when the request has not been authenticated
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(keyPrincipal{}).(*Principal)
+	return p, ok
+}
+
+// Config for an Authenticator
+type Config struct {
+	// Keys verify the signature of JWTs, when nil JWTs are rejected
+	Keys *KeySet
+
+	// Issuer is the required iss claim of JWTs, not checked when empty
+	Issuer string
+
+	// Audience is the required aud claim of JWTs, not checked when empty
+	Audience string
+
+	// APIKeys are the static API keys which are accepted
+	APIKeys []APIKey
+}
+
+// LoadConfig returns a Config with the keys from the JWKS file and the API
+// keys file, an empty path is skipped
+func LoadConfig(jwksFile, apiKeysFile string) (Config, error) {
+	c := Config{}
+
+	if jwksFile != "" {
+		ks, err := LoadJWKS(jwksFile)
+		if err != nil {
+			return c, err
+		}
+		c.Keys = ks
+	}
+
+	if apiKeysFile != "" {
+		keys, err := LoadAPIKeys(apiKeysFile)
+		if err != nil {
+			return c, err
+		}
+		c.APIKeys = keys
+	}
+
+	return c, nil
+}
+
+// Authenticator verifies the credentials of requests
+type Authenticator struct {
+	log      hclog.Logger
+	keys     *KeySet
+	issuer   string
+	audience string
+	apiKeys  map[string]*Principal
+}
+
+// NewAuthenticator creates an Authenticator accepting the JWTs and API keys in
+// the config
+func NewAuthenticator(c Config, l hclog.Logger) *Authenticator {
+	a := &Authenticator{log: l, keys: c.Keys, issuer: c.Issuer, audience: c.Audience, apiKeys: map[string]*Principal{}}
+	for _, k := range c.APIKeys {
+		a.apiKeys[hashKey(k.Key)] = &Principal{Subject: k.Name, Scopes: k.Scopes}
+	}
+
+	return a
+}
+
+// Authenticate returns the caller of the request from its bearer token or API
+// key, an error is returned when the credentials are missing or invalid
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		p, ok := a.apiKeys[hashKey(key)]
+		if !ok {
+			return nil, ErrInvalidAPIKey
+		}
+
+		return p, nil
+	}
+
+	h := r.Header.Get("Authorization")
+	if h == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	if len(h) < 7 || !strings.EqualFold(h[:7], "Bearer ") {
+		return nil, fmt.Errorf("%w: expected the Bearer scheme", ErrInvalidToken)
+	}
+
+	return a.verifyToken(strings.TrimSpace(h[7:]))
+}
+
+// Require returns middleware which only calls the next handler when the
+// request is authenticated and the caller has been granted the scope.
+// Unauthenticated requests get a 401 response and requests without the scope a
+// 403 response
+func (a *Authenticator) Require(scope string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			l := requestlog.Logger(r.Context(), a.log)
+
+			p, err := a.Authenticate(r)
+			if err != nil {
+				l.Warn("Request not authenticated", "error", err)
+
+				rw.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(rw, http.StatusUnauthorized, err)
+				return
+			}
+
+			if !p.HasScope(scope) {
+				l.Warn("Request not authorized", "subject", p.Subject, "scope", scope)
+
+				rw.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer error=\"insufficient_scope\", scope=%q", scope))
+				writeError(rw, http.StatusForbidden, fmt.Errorf("%w, %s is required", ErrInsufficientScope, scope))
+				return
+			}
+
+			l.Debug("Request authorized", "subject", p.Subject, "scope", scope)
+
+			ctx := context.WithValue(r.Context(), keyPrincipal{}, p)
+			next.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeError writes the error as a JSON message in the same format as the
+// errors returned by the services
+func writeError(rw http.ResponseWriter, status int, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	json.NewEncoder(rw).Encode(&struct {
+		Message string `json:"message"`
+	}{err.Error()})
+}
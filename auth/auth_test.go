@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// testKeys returns an RSA key and a key set containing its public key and the
+// test secret
+func testKeys(t *testing.T) (*rsa.PrivateKey, *KeySet) {
+	rk, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "alg": "RS256", "use": "sig", "n": %q, "e": %q},
+		{"kty": "oct", "kid": "hmac", "k": %q},
+		{"kty": "RSA", "kid": "encryption", "use": "enc", "n": "AQAB", "e": "AQAB"}
+	]}`, b64(rk.N.Bytes()), b64(big.NewInt(int64(rk.E)).Bytes()), b64(testSecret))
+
+	ks, err := ParseJWKS([]byte(jwks))
+	assert.NoError(t, err)
+
+	return rk, ks
+}
+
+// sign returns a JWT with the claims signed by the method and key
+func sign(t *testing.T, m jwt.SigningMethod, kid string, k interface{}, claims jwt.MapClaims) string {
+	tok := jwt.NewWithClaims(m, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+
+	s, err := tok.SignedString(k)
+	assert.NoError(t, err)
+
+	return s
+}
+
+// validClaims returns claims for a token granting the scopes
+func validClaims(scope string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "alice",
+		"iss":   "https://auth.example.com",
+		"aud":   []string{"products-api"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": scope,
+	}
+}
+
+// newTestRouter returns a router requiring the scope for a handler which
+// writes the subject of the caller
+func newTestRouter(a *Authenticator, scope string) *mux.Router {
+	r := mux.NewRouter()
+	r.Use(a.Require(scope))
+	r.HandleFunc("/products", func(rw http.ResponseWriter, r *http.Request) {
+		p, _ := FromContext(r.Context())
+		rw.Write([]byte(p.Subject))
+	})
+
+	return r
+}
+
+func TestRequire(t *testing.T) {
+	rk, ks := testKeys(t)
+	a := NewAuthenticator(Config{
+		Keys:     ks,
+		Issuer:   "https://auth.example.com",
+		Audience: "products-api",
+		APIKeys:  []APIKey{{Name: "ci", Key: "ci-key-0123456789", Scopes: []string{"products:write"}}},
+	}, hclog.NewNullLogger())
+
+	expired := validClaims("products:write")
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+
+	noExpiry := validClaims("products:write")
+	delete(noExpiry, "exp")
+
+	wrongAudience := validClaims("products:write")
+	wrongAudience["aud"] = "images"
+
+	singleAudience := validClaims("products:write")
+	singleAudience["aud"] = "products-api"
+
+	noAudience := validClaims("products:write")
+	delete(noAudience, "aud")
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	// an HS256 token signed with the RSA public key must not be accepted
+	pub, err := x509.MarshalPKIXPublicKey(&rk.PublicKey)
+	assert.NoError(t, err)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+		subject string
+	}{
+		{"rs256 token", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, validClaims("products:read products:write"))}, http.StatusOK, "alice"},
+		{"hs256 token", map[string]string{"Authorization": "bearer " + sign(t, jwt.SigningMethodHS256, "hmac", testSecret, validClaims("products:write"))}, http.StatusOK, "alice"},
+		{"hs256 token without kid", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodHS256, "", testSecret, validClaims("products:write"))}, http.StatusOK, "alice"},
+		{"single audience", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, singleAudience)}, http.StatusOK, "alice"},
+		{"api key", map[string]string{HeaderAPIKey: "ci-key-0123456789"}, http.StatusOK, "ci"},
+		{"no credentials", nil, http.StatusUnauthorized, ""},
+		{"unknown api key", map[string]string{HeaderAPIKey: "guess"}, http.StatusUnauthorized, ""},
+		{"basic auth", map[string]string{"Authorization": "Basic YWxpY2U6c2VjcmV0"}, http.StatusUnauthorized, ""},
+		{"expired token", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, expired)}, http.StatusUnauthorized, ""},
+		{"token without expiry", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, noExpiry)}, http.StatusUnauthorized, ""},
+		{"wrong audience", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, wrongAudience)}, http.StatusUnauthorized, ""},
+		{"missing audience", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, noAudience)}, http.StatusUnauthorized, ""},
+		{"unknown signing key", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", otherKey, validClaims("products:write"))}, http.StatusUnauthorized, ""},
+		{"algorithm confusion", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodHS256, "rsa", pubPEM, validClaims("products:write"))}, http.StatusUnauthorized, ""},
+		{"missing scope", map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rk, validClaims("products:read"))}, http.StatusForbidden, ""},
+	}
+
+	router := newTestRouter(a, "products:write")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/products", nil)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+
+			router.ServeHTTP(rw, r)
+
+			assert.Equal(t, tc.status, rw.Code)
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.subject, rw.Body.String())
+			} else {
+				assert.Contains(t, rw.Header().Get("WWW-Authenticate"), "Bearer")
+				assert.Contains(t, rw.Body.String(), `"message"`)
+			}
+		})
+	}
+}
+
+func TestRequireWithoutKeysRejectsTokens(t *testing.T) {
+	a := NewAuthenticator(Config{}, hclog.NewNullLogger())
+
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/products", nil)
+	r.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodHS256, "", testSecret, validClaims("products:write")))
+
+	newTestRouter(a, "products:write").ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+}
+
+func TestParseJWKSInvalidKeys(t *testing.T) {
+	for _, jwks := range []string{
+		`{"keys": []}`,
+		`{"keys": [{"kty": "oct", "k": "c2hvcnQ"}]}`,
+		`{"keys": [{"kty": "EC", "crv": "P-256"}]}`,
+		`{"keys": [{"kty": "RSA", "alg": "HS256", "n": "AQAB", "e": "AQAB"}]}`,
+		`{"keys": [{"kty": "RSA", "n": "", "e": "AQAB"}]}`,
+	} {
+		_, err := ParseJWKS([]byte(jwks))
+		assert.Error(t, err, jwks)
+	}
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	f, err := ioutil.TempFile("", "keys*.json")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	f.WriteString(`[{"name": "ci", "key": "ci-key-0123456789", "scopes": ["products:write"]}]`)
+	f.Close()
+
+	keys, err := LoadAPIKeys(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, []APIKey{{Name: "ci", Key: "ci-key-0123456789", Scopes: []string{"products:write"}}}, keys)
+
+	ioutil.WriteFile(f.Name(), []byte(`[{"name": "ci", "key": "short"}]`), 0600)
+	_, err = LoadAPIKeys(f.Name())
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+module github.com/JamieBShaw/golang-mux-rest-api/auth
+
+go 1.14
+
+require (
+	github.com/JamieBShaw/golang-mux-rest-api/requestlog v0.0.0
+	github.com/golang-jwt/jwt/v4 v4.5.2
+	github.com/gorilla/mux v1.8.0
+	github.com/hashicorp/go-hclog v0.14.1
+	github.com/stretchr/testify v1.6.1
+)
+
+replace github.com/JamieBShaw/golang-mux-rest-api/requestlog => ../requestlog
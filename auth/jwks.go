@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ErrUnknownKey is an error raised when no key in the set can verify a JWT
+var ErrUnknownKey = fmt.Errorf("No key found to verify the token")
+
+// KeySet is a set of keys used to verify JWTs, loaded from a JSON Web Key Set.
+// RSA keys verify RS256 tokens and symmetric keys HS256 tokens
+type KeySet struct {
+	keys []*key
+}
+
+// key is a key from the set and the algorithm it verifies
+type key struct {
+	id  string
+	alg string
+	key interface{}
+}
+
+// jwk is a JSON Web Key as defined in RFC 7517, only the members needed for
+// RSA and symmetric keys are decoded
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// LoadJWKS reads a JSON Web Key Set from the file at path
+func LoadJWKS(path string) (*KeySet, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read JWKS file: %w", err)
+	}
+
+	ks, err := ParseJWKS(b)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse JWKS file %s: %w", path, err)
+	}
+
+	return ks, nil
+}
+
+// ParseJWKS parses a JSON Web Key Set, keys which are not for signatures are
+// ignored
+func ParseJWKS(b []byte) (*KeySet, error) {
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+
+	err := json.Unmarshal(b, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{}
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		parsed, err := parseJWK(k)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid key %d %q: %w", i, k.Kid, err)
+		}
+		ks.keys = append(ks.keys, parsed)
+	}
+
+	if len(ks.keys) == 0 {
+		return nil, fmt.Errorf("No signing keys in the key set")
+	}
+
+	return ks, nil
+}
+
+// parseJWK returns the key for the JWK
+func parseJWK(k jwk) (*key, error) {
+	switch k.Kty {
+	case "RSA":
+		if k.Alg != "" && k.Alg != "RS256" {
+			return nil, fmt.Errorf("Unsupported algorithm %s for an RSA key", k.Alg)
+		}
+
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid modulus: %w", err)
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid exponent: %w", err)
+		}
+
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("Invalid exponent")
+		}
+
+		return &key{id: k.Kid, alg: "RS256", key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+
+	case "oct":
+		if k.Alg != "" && k.Alg != "HS256" {
+			return nil, fmt.Errorf("Unsupported algorithm %s for a symmetric key", k.Alg)
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid key value: %w", err)
+		}
+
+		// RFC 7518 requires keys at least as long as the hash output
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("Symmetric keys must be at least 256 bits")
+		}
+
+		return &key{id: k.Kid, alg: "HS256", key: secret}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported key type %q, expected RSA or oct", k.Kty)
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("Empty value")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// verificationKey returns the key to verify the token with, it is chosen by
+// the kid header and must be for the algorithm of the token so a token can not
+// be verified with a key meant for a different algorithm. The kid can be left
+// out when only one key can verify the algorithm
+func (ks *KeySet) verificationKey(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	var found *key
+	for _, k := range ks.keys {
+		if k.alg != t.Method.Alg() || (kid != "" && k.id != kid) {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("%w, the kid header is required", ErrUnknownKey)
+		}
+		found = k
+	}
+
+	if found == nil {
+		return nil, ErrUnknownKey
+	}
+
+	return found.key, nil
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// validMethods are the signing algorithms accepted for JWTs
+var validMethods = []string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}
+
+// verifyToken verifies the signature and claims of the JWT and returns the
+// caller it was issued to
+func (a *Authenticator) verifyToken(token string) (*Principal, error) {
+	if a.keys == nil {
+		return nil, fmt.Errorf("%w: no keys are configured", ErrInvalidToken)
+	}
+
+	p := &jwt.Parser{ValidMethods: validMethods}
+	claims := jwt.MapClaims{}
+
+	// the expiry and not before times are checked by the parser
+	_, err := p.ParseWithClaims(token, claims, a.keys.verificationKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	sub, _ := claims["sub"].(string)
+
+	return &Principal{Subject: sub, Scopes: scopes(claims)}, nil
+}
+
+// scopes returns the scopes in the space separated scope claim
+func scopes(claims jwt.MapClaims) []string {
+	s, _ := claims["scope"].(string)
+	return strings.Fields(s)
+}
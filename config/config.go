@@ -0,0 +1,340 @@
+// Package config loads the configuration of a service into a struct from
+// defaults, an optional YAML file, environment variables and flags, each
+// overriding the ones before it.
+//
+// The settings are the struct fields with a config tag giving their name, the
+// name is used as the flag and the YAML key and, upper cased with the prefix,
+// as the environment variable:
+//
+//	type Config struct {
+//		ListenAddr string        `config:"listen_addr" default:":9090" usage:"address to listen on"`
+//		Timeout    time.Duration `config:"timeout" default:"5s" usage:"request timeout"`
+//	}
+//
+// Fields can be a string, bool, int, int64, float64, time.Duration or a
+// []string given as a comma separated list. Fields tagged secret:"true" are
+// masked when the configuration is printed
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FileSetting is the name of the setting giving the path of the YAML file
+const FileSetting = "config_file"
+
+// Validator is implemented by configurations which check their settings once
+// they have been loaded
+type Validator interface {
+	Validate() error
+}
+
+// lookupEnv returns the value of an environment variable, tests replace it
+var lookupEnv = os.LookupEnv
+
+// setting is a field of the configuration struct
+type setting struct {
+	name   string
+	env    string
+	def    string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+// Load sets the fields of the struct c points to from the defaults, the YAML
+// file, the environment variables starting with envPrefix and the command line
+// arguments. When the struct implements Validator the loaded configuration is
+// validated. flag.ErrHelp is returned when -h is given
+func Load(name, envPrefix string, c interface{}, args []string) error {
+	settings, err := settingsOf(c, envPrefix)
+	if err != nil {
+		return err
+	}
+
+	// parse the flags first for the path of the YAML file, they are applied
+	// last so they override the other sources
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	file := fs.String(FileSetting, "", "path to a YAML configuration file, env "+envName(envPrefix, FileSetting))
+	flags := map[string]*flagValue{}
+	for _, s := range settings {
+		fv := &flagValue{value: s.def, isBool: s.value.Kind() == reflect.Bool}
+		flags[s.name] = fv
+		fs.Var(fv, s.name, fmt.Sprintf("%s, env %s", s.usage, s.env))
+	}
+
+	err = fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range settings {
+		err := s.set(s.def)
+		if err != nil {
+			return fmt.Errorf("Invalid default for %s: %w", s.name, err)
+		}
+	}
+
+	if *file == "" {
+		*file, _ = lookupEnv(envName(envPrefix, FileSetting))
+	}
+	if *file != "" {
+		err := loadFile(*file, settings)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range settings {
+		v, ok := lookupEnv(s.env)
+		if !ok {
+			continue
+		}
+
+		err := s.set(v)
+		if err != nil {
+			return fmt.Errorf("Invalid value for environment variable %s: %w", s.env, err)
+		}
+	}
+
+	for _, s := range settings {
+		fv := flags[s.name]
+		if !fv.set {
+			continue
+		}
+
+		err := s.set(fv.value)
+		if err != nil {
+			return fmt.Errorf("Invalid value for flag -%s: %w", s.name, err)
+		}
+	}
+
+	if v, ok := c.(Validator); ok {
+		err := v.Validate()
+		if err != nil {
+			return fmt.Errorf("Invalid configuration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Values returns the names and values of the settings in c as key value pairs
+// for logging, secret values are masked
+func Values(c interface{}) []interface{} {
+	settings, err := settingsOf(c, "")
+	if err != nil {
+		return nil
+	}
+
+	kv := []interface{}{}
+	for _, s := range settings {
+		v := format(s.value)
+		if s.secret && v != "" {
+			v = "********"
+		}
+		kv = append(kv, s.name, v)
+	}
+
+	return kv
+}
+
+// settingsOf returns the settings of the struct c points to
+func settingsOf(c interface{}, envPrefix string) ([]*setting, error) {
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Expected a pointer to a struct got %T", c)
+	}
+	v = v.Elem()
+
+	settings := []*setting{}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name := f.Tag.Get("config")
+		if name == "" {
+			continue
+		}
+
+		if !supported(f.Type) {
+			return nil, fmt.Errorf("Unsupported type %s for setting %s", f.Type, name)
+		}
+
+		settings = append(settings, &setting{
+			name:   name,
+			env:    envName(envPrefix, name),
+			def:    f.Tag.Get("default"),
+			usage:  f.Tag.Get("usage"),
+			secret: f.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+
+	return settings, nil
+}
+
+// envName returns the environment variable for the setting
+func envName(prefix, name string) string {
+	if prefix == "" {
+		return strings.ToUpper(name)
+	}
+
+	return strings.ToUpper(prefix + "_" + name)
+}
+
+// loadFile sets the settings found in the YAML file
+func loadFile(path string, settings []*setting) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to read configuration file: %w", err)
+	}
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(b, &values)
+	if err != nil {
+		return fmt.Errorf("Unable to parse configuration file %s: %w", path, err)
+	}
+
+	known := map[string]*setting{}
+	for _, s := range settings {
+		known[s.name] = s
+	}
+
+	for k, v := range values {
+		s, ok := known[k]
+		if !ok {
+			return fmt.Errorf("Unknown setting %s in configuration file %s", k, path)
+		}
+
+		// lists are written as YAML sequences, other values as scalars
+		str := fmt.Sprint(v)
+		if v == nil {
+			str = ""
+		}
+		if l, ok := v.([]interface{}); ok {
+			items := []string{}
+			for _, i := range l {
+				items = append(items, fmt.Sprint(i))
+			}
+			str = strings.Join(items, ",")
+		}
+
+		err := s.set(str)
+		if err != nil {
+			return fmt.Errorf("Invalid value for %s in configuration file %s: %w", k, path, err)
+		}
+	}
+
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// supported returns true when settings can be parsed into the type
+func supported(t reflect.Type) bool {
+	if t == durationType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+
+	return false
+}
+
+// set parses s into the value of the setting
+func (st *setting) set(s string) error {
+	v := st.value
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		items := []string{}
+		for _, i := range strings.Split(s, ",") {
+			if i = strings.TrimSpace(i); i != "" {
+				items = append(items, i)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	}
+
+	return nil
+}
+
+// format returns the value in the format it is set with
+func format(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	if v.Kind() == reflect.Slice {
+		return strings.Join(v.Interface().([]string), ",")
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// flagValue keeps the value of a flag until the other sources are loaded
+type flagValue struct {
+	value  string
+	isBool bool
+	set    bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.value
+}
+
+func (f *flagValue) Set(s string) error {
+	f.value = s
+	f.set = true
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	Addr     string        `config:"addr" default:":9090" usage:"address to listen on"`
+	Debug    bool          `config:"debug" default:"false" usage:"log debug messages"`
+	Retries  int           `config:"retries" default:"3" usage:"number of retries"`
+	Ratio    float64       `config:"ratio" default:"0.5" usage:"a ratio"`
+	Timeout  time.Duration `config:"timeout" default:"5s" usage:"request timeout"`
+	Origins  []string      `config:"origins" default:"http://localhost:3000" usage:"allowed origins"`
+	Password string        `config:"password" secret:"true" usage:"a secret"`
+
+	// not a setting
+	Other string
+}
+
+func (c *testConfig) Validate() error {
+	if c.Retries < 0 {
+		return assert.AnError
+	}
+
+	return nil
+}
+
+// withEnv replaces the environment variables for the test
+func withEnv(t *testing.T, env map[string]string) {
+	lookupEnv = func(k string) (string, bool) {
+		v, ok := env[k]
+		return v, ok
+	}
+	t.Cleanup(func() {
+		lookupEnv = func(string) (string, bool) { return "", false }
+	})
+}
+
+// writeFile writes a YAML configuration file and returns its path
+func writeFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	p := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(p, []byte(content), 0644))
+
+	return p
+}
+
+func TestLoadDefaults(t *testing.T) {
+	withEnv(t, nil)
+
+	c := &testConfig{}
+	assert.NoError(t, Load("test", "TEST", c, nil))
+
+	assert.Equal(t, ":9090", c.Addr)
+	assert.False(t, c.Debug)
+	assert.Equal(t, 3, c.Retries)
+	assert.Equal(t, 0.5, c.Ratio)
+	assert.Equal(t, 5*time.Second, c.Timeout)
+	assert.Equal(t, []string{"http://localhost:3000"}, c.Origins)
+}
+
+func TestLoadOverridesInOrder(t *testing.T) {
+	file := writeFile(t, `
+addr: ":8080"
+retries: 5
+timeout: 10s
+origins:
+  - https://a.example.com
+  - https://b.example.com
+`)
+	withEnv(t, map[string]string{
+		"TEST_CONFIG_FILE": file,
+		"TEST_RETRIES":     "7",
+		"TEST_DEBUG":       "true",
+	})
+
+	c := &testConfig{}
+	assert.NoError(t, Load("test", "TEST", c, []string{"-retries", "9", "-ratio=0.25"}))
+
+	// the file overrides the defaults
+	assert.Equal(t, ":8080", c.Addr)
+	assert.Equal(t, 10*time.Second, c.Timeout)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, c.Origins)
+
+	// the environment overrides the file
+	assert.True(t, c.Debug)
+
+	// flags override the environment
+	assert.Equal(t, 9, c.Retries)
+	assert.Equal(t, 0.25, c.Ratio)
+}
+
+func TestLoadFileFromFlag(t *testing.T) {
+	withEnv(t, nil)
+	file := writeFile(t, "addr: \":8080\"\n")
+
+	c := &testConfig{}
+	assert.NoError(t, Load("test", "TEST", c, []string{"-config_file", file}))
+	assert.Equal(t, ":8080", c.Addr)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		file string
+		args []string
+	}{
+		{name: "invalid flag", args: []string{"-timeout", "soon"}},
+		{name: "unknown flag", args: []string{"-unknown", "1"}},
+		{name: "invalid environment variable", env: map[string]string{"TEST_RETRIES": "many"}},
+		{name: "unknown setting in file", file: "unknown: 1\n"},
+		{name: "invalid value in file", file: "debug: maybe\n"},
+		{name: "validation fails", args: []string{"-retries", "-1"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := tc.args
+			if tc.file != "" {
+				args = append(args, "-config_file", writeFile(t, tc.file))
+			}
+			withEnv(t, tc.env)
+
+			assert.Error(t, Load("test", "TEST", &testConfig{}, args))
+		})
+	}
+}
+
+func TestLoadHelp(t *testing.T) {
+	withEnv(t, nil)
+
+	assert.Equal(t, flag.ErrHelp, Load("test", "TEST", &testConfig{}, []string{"-h"}))
+}
+
+func TestValuesMasksSecrets(t *testing.T) {
+	c := &testConfig{Addr: ":9090", Other: "ignored", Timeout: time.Second, Origins: []string{"a", "b"}, Password: "hunter2"}
+
+	assert.Equal(t, []interface{}{
+		"addr", ":9090",
+		"debug", "false",
+		"retries", "0",
+		"ratio", "0",
+		"timeout", "1s",
+		"origins", "a,b",
+		"password", "********",
+	}, Values(c))
+}
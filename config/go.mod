@@ -0,0 +1,8 @@
+module github.com/JamieBShaw/golang-mux-rest-api/config
+
+go 1.14
+
+require (
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v2 v2.3.0
+)
@@ -0,0 +1,34 @@
+// Command devcerts generates a development CA and the certificates to run the
+// currency service and products-rest-api with mutual TLS on one machine:
+//
+//	go run ./cmd/devcerts -out certs
+//	go run . -tls_cert_file certs/server.pem -tls_key_file certs/server-key.pem -tls_client_ca_file certs/ca.pem
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/security"
+	"github.com/hashicorp/go-hclog"
+)
+
+var out = flag.String("out", "certs", "directory the certificates and keys are written to")
+var hosts = flag.String("hosts", "localhost,127.0.0.1,::1", "comma separated host names and IP addresses the server certificate is valid for")
+var validFor = flag.Duration("valid_for", 365*24*time.Hour, "how long the certificates are valid for")
+
+func main() {
+	flag.Parse()
+
+	log := hclog.Default()
+
+	err := security.GenerateDevCerts(*out, strings.Split(*hosts, ","), *validFor)
+	if err != nil {
+		log.Error("Unable to generate certificates", "error", err)
+		os.Exit(1)
+	}
+
+	log.Info("Generated development certificates", "dir", *out, "hosts", *hosts)
+}
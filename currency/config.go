@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Config is the configuration of the currency service, see config.Load for how
+// it is set
+type Config struct {
+	ListenAddr         string        `config:"listen_addr" default:":9092" usage:"address the gRPC server listens on"`
+	MetricsAddr        string        `config:"metrics_addr" default:":9093" usage:"address the Prometheus /metrics endpoint listens on"`
+	LogLevel           string        `config:"log_level" default:"info" usage:"log level, trace, debug, info, warn or error"`
+	RateProvider       string        `config:"rate_provider" default:"ecb" usage:"source of exchange rates, ecb, file or static"`
+	RateFile           string        `config:"rate_file" usage:"path to an xml, json or csv rates file used by the file rate provider"`
+	StaticRates        string        `config:"static_rates" usage:"rates used by the static rate provider in the format USD=1.18,GBP=0.86"`
+	Simulate           bool          `config:"simulate" default:"false" usage:"randomly change the rates, for demonstration and integration tests"`
+	SimulateSeed       int64         `config:"simulate_seed" default:"1" usage:"seed for the simulated rate changes, the same seed produces the same rates"`
+	SimulateVolatility float64       `config:"simulate_volatility" default:"0.01" usage:"largest simulated change to a rate each tick as a fraction of the rate"`
+	SimulateDrift      float64       `config:"simulate_drift" default:"0" usage:"change added to every rate each tick as a fraction of the rate"`
+	SimulateInterval   time.Duration `config:"simulate_interval" default:"5s" usage:"time between simulated rate changes"`
+	TraceOutput        string        `config:"trace_output" usage:"where to write trace spans, stdout or a file path, tracing is disabled when empty"`
+	TLSCertFile        string        `config:"tls_cert_file" usage:"PEM certificate the gRPC server uses for TLS, the server is plaintext when empty"`
+	TLSKeyFile         string        `config:"tls_key_file" usage:"PEM private key of tls_cert_file"`
+	TLSClientCAFile    string        `config:"tls_client_ca_file" usage:"PEM CA certificates client certificates must be signed by, enables mutual TLS"`
+	AuthTokens         []string      `config:"auth_tokens" secret:"true" usage:"comma separated bearer tokens clients must send, requires TLS, calls are not authenticated when empty"`
+}
+
+// Validate checks the settings can be used to start the service
+func (c *Config) Validate() error {
+	if c.ListenAddr == "" || c.MetricsAddr == "" {
+		return fmt.Errorf("listen_addr and metrics_addr must be set")
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("tls_cert_file and tls_key_file must be set together")
+	}
+
+	if c.TLSClientCAFile != "" && c.TLSCertFile == "" {
+		return fmt.Errorf("tls_client_ca_file requires tls_cert_file and tls_key_file")
+	}
+
+	// tokens sent in plaintext could be read from the network
+	if len(c.AuthTokens) > 0 && c.TLSCertFile == "" {
+		return fmt.Errorf("auth_tokens requires tls_cert_file and tls_key_file")
+	}
+
+	if hclog.LevelFromString(c.LogLevel) == hclog.NoLevel {
+		return fmt.Errorf("Unknown log level %s", c.LogLevel)
+	}
+
+	return nil
+}
+
+// simulation returns the configuration of the rate simulator
+func (c *Config) simulation() data.SimulationConfig {
+	return data.SimulationConfig{
+		Seed:       c.SimulateSeed,
+		Volatility: c.SimulateVolatility,
+		Drift:      c.SimulateDrift,
+		Interval:   c.SimulateInterval,
+	}
+}
@@ -0,0 +1,27 @@
+package data
+
+import "time"
+
+// Clock tells the time and waits for time to pass, it allows tests to control
+// time with a fake clock
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is a Clock using the system time
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
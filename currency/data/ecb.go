@@ -0,0 +1,194 @@
+package data
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ECB reference rate feeds
+const (
+	ECBDailyURL   = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+	ECBHistoryURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+)
+
+// ECBProvider is a RateProvider which fetches the reference rates published
+// by the European Central Bank. Feeds are fetched with conditional requests so
+// an unchanged feed is not downloaded again
+type ECBProvider struct {
+	client     *http.Client
+	dailyURL   string
+	historyURL string
+
+	mu    sync.Mutex
+	feeds map[string]*ecbFeed
+}
+
+// ecbFeed is the last response for a feed, the validators are sent with the
+// next request and the rates are reused when the feed has not been modified
+type ecbFeed struct {
+	etag         string
+	lastModified string
+	rates        []*RateSnapshot
+}
+
+// NewECBProvider creates an ECBProvider using the public ECB feeds
+func NewECBProvider() *ECBProvider {
+	return &ECBProvider{
+		client:     &http.Client{Timeout: 30 * time.Second},
+		dailyURL:   ECBDailyURL,
+		historyURL: ECBHistoryURL,
+		feeds:      map[string]*ecbFeed{},
+	}
+}
+
+// Name returns the name of the provider
+func (e *ECBProvider) Name() string {
+	return "ecb"
+}
+
+// Latest fetches the rates published on the last business day
+func (e *ECBProvider) Latest() (*RateSnapshot, error) {
+	rss, err := e.fetch(e.dailyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return rss[len(rss)-1], nil
+}
+
+// History fetches the rates published over the last 90 days
+func (e *ECBProvider) History() ([]*RateSnapshot, error) {
+	return e.fetch(e.historyURL)
+}
+
+// fetch returns the rates in the feed, the previous rates are returned when
+// the ECB reports the feed has not been modified
+func (e *ECBProvider) fetch(url string) ([]*RateSnapshot, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	f, cached := e.feeds[url]
+	e.mu.Unlock()
+
+	if cached {
+		if f.etag != "" {
+			req.Header.Set("If-None-Match", f.etag)
+		}
+		if f.lastModified != "" {
+			req.Header.Set("If-Modified-Since", f.lastModified)
+		}
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to fetch rates from ECB: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && cached {
+		return copySnapshots(f.rates), nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Expected status code 200 got %d", res.StatusCode)
+	}
+
+	rss, err := decodeECB(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.feeds[url] = &ecbFeed{
+		etag:         res.Header.Get("ETag"),
+		lastModified: res.Header.Get("Last-Modified"),
+		rates:        copySnapshots(rss),
+	}
+	e.mu.Unlock()
+
+	return rss, nil
+}
+
+// copySnapshots returns a copy of the snapshots so the cached rates can not be
+// changed by the caller
+func copySnapshots(rss []*RateSnapshot) []*RateSnapshot {
+	cs := make([]*RateSnapshot, len(rss))
+	for i, rs := range rss {
+		cs[i] = newSnapshot(rs.Date, rs.Rates)
+	}
+
+	return cs
+}
+
+// Cubes is the envelope of the ECB reference rate feeds, the daily feed
+// contains a single day and the historical feeds one day per business day
+type Cubes struct {
+	Days []DayCube `xml:"Cube>Cube"`
+}
+
+// DayCube contains the rates published on the day in the time attribute
+type DayCube struct {
+	Time     string `xml:"time,attr"`
+	CubeData []Cube `xml:"Cube"`
+}
+
+type Cube struct {
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
+
+// decodeECB reads a feed in the ECB XML format returning the snapshots
+// oldest first
+func decodeECB(r io.Reader) ([]*RateSnapshot, error) {
+	md := &Cubes{}
+	err := xml.NewDecoder(r).Decode(md)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode ECB rates: %w", err)
+	}
+
+	if len(md.Days) == 0 {
+		return nil, fmt.Errorf("No rates found in ECB feed")
+	}
+
+	rss := []*RateSnapshot{}
+	for _, d := range md.Days {
+		rs, err := d.parse()
+		if err != nil {
+			return nil, err
+		}
+		rss = append(rss, rs)
+	}
+
+	// the ECB publishes the most recent day first
+	sortSnapshots(rss)
+
+	return rss, nil
+}
+
+// parse returns the rates for the day including the EUR base rate
+func (d DayCube) parse() (*RateSnapshot, error) {
+	date, err := time.Parse(DateFormat, d.Time)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid date %q in rates: %w", d.Time, err)
+	}
+
+	rates := map[string]float64{}
+	for _, c := range d.CubeData {
+
+		r, err := strconv.ParseFloat(c.Rate, 64)
+		if err != nil {
+			return nil, err
+		}
+		rates[c.Currency] = r
+	}
+
+	return newSnapshot(date, rates), nil
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileProvider is a RateProvider which reads rates from a local file, the
+// format is chosen from the file extension:
+//
+//	.xml  the ECB daily or historical feed format
+//	.json an array of {"date": "2006-01-02", "rates": {"USD": 1.18}}
+//	.csv  rows of date,currency,rate with an optional header
+//
+// Rates are always relative to EUR
+type FileProvider struct {
+	path string
+}
+
+// NewFileProvider creates a FileProvider for the file at path
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path}
+}
+
+// Name returns the name of the provider
+func (f *FileProvider) Name() string {
+	return "file"
+}
+
+// Latest returns the most recent rates in the file
+func (f *FileProvider) Latest() (*RateSnapshot, error) {
+	rss, err := f.History()
+	if err != nil {
+		return nil, err
+	}
+
+	return rss[len(rss)-1], nil
+}
+
+// History returns all of the rates in the file, oldest first
+func (f *FileProvider) History() ([]*RateSnapshot, error) {
+	r, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open rates file: %w", err)
+	}
+	defer r.Close()
+
+	var rss []*RateSnapshot
+	switch ext := strings.ToLower(filepath.Ext(f.path)); ext {
+	case ".xml":
+		rss, err = decodeECB(r)
+	case ".json":
+		rss, err = decodeJSONRates(r)
+	case ".csv":
+		rss, err = decodeCSVRates(r)
+	default:
+		return nil, fmt.Errorf("Unsupported rates file extension %q, expected .xml, .json or .csv", ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rss) == 0 {
+		return nil, fmt.Errorf("No rates found in %s", f.path)
+	}
+
+	return rss, nil
+}
+
+// jsonRates is a single day in the JSON rates format
+type jsonRates struct {
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+func decodeJSONRates(r io.Reader) ([]*RateSnapshot, error) {
+	days := []jsonRates{}
+	err := json.NewDecoder(r).Decode(&days)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode JSON rates: %w", err)
+	}
+
+	rss := []*RateSnapshot{}
+	for _, d := range days {
+		date, err := time.Parse(DateFormat, d.Date)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid date %q in rates: %w", d.Date, err)
+		}
+		rss = append(rss, newSnapshot(date, d.Rates))
+	}
+
+	sortSnapshots(rss)
+
+	return rss, nil
+}
+
+func decodeCSVRates(r io.Reader) ([]*RateSnapshot, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode CSV rates: %w", err)
+	}
+
+	days := map[time.Time]map[string]float64{}
+	for i, row := range rows {
+		if len(row) != 3 {
+			return nil, fmt.Errorf("Invalid CSV rates on line %d, expected date,currency,rate", i+1)
+		}
+
+		// skip the header
+		if i == 0 && strings.EqualFold(row[0], "date") {
+			continue
+		}
+
+		date, err := time.Parse(DateFormat, strings.TrimSpace(row[0]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid date %q on line %d", row[0], i+1)
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(row[2]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rate %q on line %d", row[2], i+1)
+		}
+
+		if days[date] == nil {
+			days[date] = map[string]float64{}
+		}
+		days[date][strings.TrimSpace(row[1])] = rate
+	}
+
+	rss := []*RateSnapshot{}
+	for date, rates := range days {
+		rss = append(rss, newSnapshot(date, rates))
+	}
+
+	sortSnapshots(rss)
+
+	return rss, nil
+}
+
+// sortSnapshots orders the snapshots oldest first
+func sortSnapshots(rss []*RateSnapshot) {
+	sort.Slice(rss, func(i, j int) bool { return rss[i].Date.Before(rss[j].Date) })
+}
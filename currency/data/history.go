@@ -0,0 +1,75 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DateFormat is the format of the dates used by the ECB feeds and the API
+const DateFormat = "2006-01-02"
+
+// ErrNoHistory is an error raised when there are no rates on or before a date
+var ErrNoHistory = fmt.Errorf("No rates available on or before the requested date")
+
+// RateHistory stores the EUR based rates published on each day
+// RateHistory is safe for concurrent use
+type RateHistory struct {
+	mu    sync.RWMutex
+	dates []time.Time // sorted oldest first
+	rates map[time.Time]map[string]float64
+}
+
+// NewRateHistory creates an empty RateHistory
+func NewRateHistory() *RateHistory {
+	return &RateHistory{rates: map[time.Time]map[string]float64{}}
+}
+
+// Add stores the rates published on the given date, replacing any rates
+// already stored for that date
+func (h *RateHistory) Add(date time.Time, rates map[string]float64) {
+	date = truncateDate(date)
+
+	// copy so later changes to the callers map are not reflected
+	rc := make(map[string]float64, len(rates))
+	for k, v := range rates {
+		rc[k] = v
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.rates[date]; !ok {
+		i := sort.Search(len(h.dates), func(i int) bool { return !h.dates[i].Before(date) })
+		h.dates = append(h.dates, time.Time{})
+		copy(h.dates[i+1:], h.dates[i:])
+		h.dates[i] = date
+	}
+	h.rates[date] = rc
+}
+
+// RatesAt returns the rates published on the given date or, when nothing was
+// published that day, the closest earlier date. The publication date of the
+// returned rates is also returned
+func (h *RateHistory) RatesAt(date time.Time) (time.Time, map[string]float64, error) {
+	date = truncateDate(date)
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	// index of the first date after the requested date
+	i := sort.Search(len(h.dates), func(i int) bool { return h.dates[i].After(date) })
+	if i == 0 {
+		return time.Time{}, nil, ErrNoHistory
+	}
+
+	d := h.dates[i-1]
+	return d, h.rates[d], nil
+}
+
+// truncateDate removes the time of day so dates can be used as map keys
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func loadTestHistory(t *testing.T) *RateHistory {
+	rss, err := NewFileProvider("testdata/eurofxref-hist.xml").History()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewRateHistory()
+	for _, rs := range rss {
+		h.Add(rs.Date, rs.Rates)
+	}
+
+	return h
+}
+
+func date(t *testing.T, s string) time.Time {
+	d, err := time.Parse(DateFormat, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
+func TestRatesAtPublishedDate(t *testing.T) {
+	h := loadTestHistory(t)
+
+	published, rates, err := h.RatesAt(date(t, "2026-01-30"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if published.Format(DateFormat) != "2026-01-30" {
+		t.Fatalf("expected rates from 2026-01-30 got %s", published.Format(DateFormat))
+	}
+
+	if rates["USD"] != 1.19 || rates["EUR"] != 1 {
+		t.Fatalf("unexpected rates %#v", rates)
+	}
+}
+
+func TestRatesAtWeekendUsesPreviousBusinessDay(t *testing.T) {
+	h := loadTestHistory(t)
+
+	// 2026-01-31 is a Saturday
+	published, _, err := h.RatesAt(date(t, "2026-01-31").Add(15 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if published.Format(DateFormat) != "2026-01-30" {
+		t.Fatalf("expected rates from 2026-01-30 got %s", published.Format(DateFormat))
+	}
+}
+
+func TestRatesAtBeforeHistoryReturnsErr(t *testing.T) {
+	h := loadTestHistory(t)
+
+	_, _, err := h.RatesAt(date(t, "2025-12-31"))
+	if err != ErrNoHistory {
+		t.Fatalf("expected ErrNoHistory got %v", err)
+	}
+}
+
+func TestGetHistoricalRateCrossesBase(t *testing.T) {
+	er := &ExchangeRates{rates: map[string]float64{}, history: loadTestHistory(t)}
+
+	rate, published, err := er.GetHistoricalRate("USD", "GBP", date(t, "2026-02-01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if published.Format(DateFormat) != "2026-01-30" {
+		t.Fatalf("expected rates from 2026-01-30 got %s", published.Format(DateFormat))
+	}
+
+	if want := 0.8670 / 1.1900; math.Abs(rate-want) > 1e-9 {
+		t.Fatalf("expected rate %f got %f", want, rate)
+	}
+}
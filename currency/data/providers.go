@@ -0,0 +1,128 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateSnapshot contains the EUR based rates published on a single day
+type RateSnapshot struct {
+	Date  time.Time
+	Rates map[string]float64
+}
+
+// RateProvider defines a source of exchange rates
+// Implementations may fetch the rates from a web service, read them from a
+// file or hold a fixed set in memory
+type RateProvider interface {
+	// Name identifies the provider in logs
+	Name() string
+
+	// Latest returns the most recently published rates
+	Latest() (*RateSnapshot, error)
+}
+
+// HistoryProvider is implemented by RateProviders which can also supply the
+// rates published on previous days
+type HistoryProvider interface {
+	// History returns the snapshots available from the source, oldest first
+	History() ([]*RateSnapshot, error)
+}
+
+// ProviderConfig selects and configures a RateProvider
+type ProviderConfig struct {
+	// Type of provider, ecb, file or static
+	Type string
+
+	// Path to the rates file used by the file provider, the format is
+	// chosen from the extension, .xml, .json or .csv
+	Path string
+
+	// Rates used by the static provider in the format USD=1.18,GBP=0.86
+	Rates string
+}
+
+// NewRateProvider creates the RateProvider described by the config
+func NewRateProvider(c ProviderConfig) (RateProvider, error) {
+	switch c.Type {
+	case "", "ecb":
+		return NewECBProvider(), nil
+	case "file":
+		if c.Path == "" {
+			return nil, fmt.Errorf("A path is required for the file rate provider")
+		}
+		return NewFileProvider(c.Path), nil
+	case "static":
+		rates, err := ParseStaticRates(c.Rates)
+		if err != nil {
+			return nil, err
+		}
+		return NewStaticProvider(rates), nil
+	}
+
+	return nil, fmt.Errorf("Unknown rate provider %q, expected ecb, file or static", c.Type)
+}
+
+// StaticProvider is a RateProvider which always returns the same rates, it is
+// useful for tests and running the service without network access
+type StaticProvider struct {
+	snapshot *RateSnapshot
+}
+
+// NewStaticProvider creates a StaticProvider for the given EUR based rates
+// published today
+func NewStaticProvider(rates map[string]float64) *StaticProvider {
+	return &StaticProvider{newSnapshot(truncateDate(time.Now()), rates)}
+}
+
+// Name returns the name of the provider
+func (s *StaticProvider) Name() string {
+	return "static"
+}
+
+// Latest returns a copy of the static rates
+func (s *StaticProvider) Latest() (*RateSnapshot, error) {
+	return newSnapshot(s.snapshot.Date, s.snapshot.Rates), nil
+}
+
+// ParseStaticRates parses rates in the format USD=1.18,GBP=0.86
+func ParseStaticRates(s string) (map[string]float64, error) {
+	rates := map[string]float64{}
+
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+
+		p := strings.SplitN(kv, "=", 2)
+		if len(p) != 2 {
+			return nil, fmt.Errorf("Invalid static rate %q, expected CURRENCY=RATE", kv)
+		}
+
+		r, err := strconv.ParseFloat(strings.TrimSpace(p[1]), 64)
+		if err != nil || r <= 0 {
+			return nil, fmt.Errorf("Invalid static rate %q, rate must be a positive number", kv)
+		}
+		rates[strings.ToUpper(strings.TrimSpace(p[0]))] = r
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("At least one rate is required for the static rate provider")
+	}
+
+	return rates, nil
+}
+
+// newSnapshot creates a snapshot with a copy of the given rates, the EUR base
+// rate is always included
+func newSnapshot(date time.Time, rates map[string]float64) *RateSnapshot {
+	rc := map[string]float64{"EUR": 1}
+	for k, v := range rates {
+		rc[k] = v
+	}
+
+	return &RateSnapshot{Date: date, Rates: rc}
+}
@@ -0,0 +1,212 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileProviderFormats(t *testing.T) {
+	for _, path := range []string{"testdata/eurofxref-hist.xml", "testdata/rates.json", "testdata/rates.csv"} {
+		t.Run(path, func(t *testing.T) {
+			rss, err := NewFileProvider(path).History()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			first, last := rss[0], rss[len(rss)-1]
+			if !first.Date.Before(last.Date) {
+				t.Fatalf("expected snapshots oldest first got %s then %s", first.Date, last.Date)
+			}
+
+			if last.Date.Format(DateFormat) != "2026-02-02" {
+				t.Fatalf("expected latest date 2026-02-02 got %s", last.Date.Format(DateFormat))
+			}
+
+			if last.Rates["USD"] != 1.1842 || last.Rates["GBP"] != 0.8651 || last.Rates["EUR"] != 1 {
+				t.Fatalf("unexpected rates %#v", last.Rates)
+			}
+		})
+	}
+}
+
+func TestFileProviderUnknownExtensionReturnsErr(t *testing.T) {
+	_, err := NewFileProvider("testdata/rates.txt").Latest()
+	if err == nil {
+		t.Fatal("expected error for unsupported extension")
+	}
+}
+
+func TestStaticProvider(t *testing.T) {
+	rates, err := ParseStaticRates("usd=1.18, GBP=0.86")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := NewStaticProvider(rates).Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rs.Rates["USD"] != 1.18 || rs.Rates["GBP"] != 0.86 || rs.Rates["EUR"] != 1 {
+		t.Fatalf("unexpected rates %#v", rs.Rates)
+	}
+}
+
+func TestParseStaticRatesInvalid(t *testing.T) {
+	for _, s := range []string{"", "USD", "USD=abc", "USD=-1"} {
+		_, err := ParseStaticRates(s)
+		if err == nil {
+			t.Fatalf("expected error parsing %q", s)
+		}
+	}
+}
+
+func TestNewRateProvider(t *testing.T) {
+	p, err := NewRateProvider(ProviderConfig{Type: "file", Path: "testdata/rates.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name() != "file" {
+		t.Fatalf("expected file provider got %s", p.Name())
+	}
+
+	_, err = NewRateProvider(ProviderConfig{Type: "unknown"})
+	if err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+}
+
+func TestECBProviderFetchesFeeds(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	p := NewECBProvider()
+	p.dailyURL = srv.URL + "/eurofxref-daily.xml"
+	p.historyURL = srv.URL + "/eurofxref-hist.xml"
+
+	rs, err := p.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Rates["USD"] != 1.1842 {
+		t.Fatalf("expected USD rate 1.1842 got %f", rs.Rates["USD"])
+	}
+
+	rss, err := p.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rss) != 3 {
+		t.Fatalf("expected 3 days of history got %d", len(rss))
+	}
+}
+
+func TestECBProviderReturnsNetworkErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	p := NewECBProvider()
+	p.dailyURL = srv.URL
+
+	_, err := p.Latest()
+	if err == nil {
+		t.Fatal("expected error when the ECB can not be reached")
+	}
+}
+
+// ecbStandIn serves a daily ECB feed which can be replaced by the test, it
+// supports conditional requests with an ETag and Last-Modified
+type ecbStandIn struct {
+	mu          sync.Mutex
+	feed        string
+	etag        string
+	modified    time.Time
+	requests    int
+	notModified int
+}
+
+// publish replaces the feed with the rates for the day
+func (e *ecbStandIn) publish(date string, usd string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.feed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube>
+		<Cube time="` + date + `">
+			<Cube currency="USD" rate="` + usd + `"/>
+			<Cube currency="GBP" rate="0.8651"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+	e.etag = `"` + date + "-" + usd + `"`
+	e.modified = time.Now()
+}
+
+func (e *ecbStandIn) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.requests++
+	if r.Header.Get("If-None-Match") == e.etag {
+		e.notModified++
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rw.Header().Set("ETag", e.etag)
+	rw.Header().Set("Last-Modified", e.modified.Format(http.TimeFormat))
+	rw.Write([]byte(e.feed))
+}
+
+// counts returns the number of requests and not modified responses
+func (e *ecbStandIn) counts() (int, int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.requests, e.notModified
+}
+
+func newTestECBProvider(t *testing.T, ecb *ecbStandIn) *ECBProvider {
+	srv := httptest.NewServer(ecb)
+	t.Cleanup(srv.Close)
+
+	p := NewECBProvider()
+	p.dailyURL = srv.URL
+	p.historyURL = srv.URL
+
+	return p
+}
+
+func TestECBProviderUsesConditionalRequests(t *testing.T) {
+	ecb := &ecbStandIn{}
+	ecb.publish("2026-10-16", "1.1842")
+	p := newTestECBProvider(t, ecb)
+
+	for i := 0; i < 2; i++ {
+		rs, err := p.Latest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rs.Rates["USD"] != 1.1842 {
+			t.Fatalf("expected USD rate 1.1842 got %f", rs.Rates["USD"])
+		}
+	}
+
+	requests, notModified := ecb.counts()
+	if requests != 2 || notModified != 1 {
+		t.Fatalf("expected 2 requests with 1 not modified got %d and %d", requests, notModified)
+	}
+
+	ecb.publish("2026-10-19", "1.19")
+	rs, err := p.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Date.Format(DateFormat) != "2026-10-19" || rs.Rates["USD"] != 1.19 {
+		t.Fatalf("expected the new rates got %s %#v", rs.Date.Format(DateFormat), rs.Rates)
+	}
+}
@@ -1,114 +1,327 @@
 package data
 
 import (
-	"encoding/xml"
 	"fmt"
-	"math/rand"
-	"net/http"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 )
 
+// ExchangeRates holds the latest and historical exchange rates
+// ExchangeRates is safe for concurrent use
 type ExchangeRates struct {
-	log   hclog.Logger
-	rates map[string]float64
+	log      hclog.Logger
+	provider RateProvider
+	history  *RateHistory
+
+	// historyLoaded is set once the history has been seeded from the
+	// provider, until then MonitorRates retries loading it
+	historyLoaded bool
+
+	// mu guards rates, published, updated, sources, snapshots, seq and
+	// historyLoaded, every change to rates creates a new snapshot
+	mu        sync.RWMutex
+	rates     map[string]float64
+	published time.Time
+	updated   map[string]time.Time
+	sources   map[string]string
+	snapshots *snapshotRing
+	seq       int
+
+	// epoch prefixes snapshot ids so ids are not reused after a restart
+	epoch string
+
+	updates chan struct{}
+
+	// loaded is closed after the rates are first loaded from the provider
+	loaded     chan struct{}
+	loadedOnce sync.Once
 }
 
-func NewRates(l hclog.Logger) (*ExchangeRates, error) {
-	er := &ExchangeRates{log: l, rates: map[string]float64{}}
+// NewRates creates ExchangeRates loading the latest rates, and the history
+// when supported, from the given provider
+func NewRates(l hclog.Logger, p RateProvider) (*ExchangeRates, error) {
+	er := &ExchangeRates{
+		log:       l,
+		provider:  p,
+		rates:     map[string]float64{},
+		updated:   map[string]time.Time{},
+		sources:   map[string]string{},
+		updates:   make(chan struct{}, 1),
+		loaded:    make(chan struct{}),
+		history:   NewRateHistory(),
+		snapshots: newSnapshotRing(MaxSnapshots),
+		epoch:     strconv.FormatInt(time.Now().Unix(), 36),
+	}
 
-	err := er.getRates()
+	// start with an empty snapshot so there is always a latest snapshot
+	er.snapshotLocked()
 
-	return er, err
+	_, err := er.getRates()
+	if err != nil {
+		return er, err
+	}
+
+	er.loadHistory()
+
+	return er, nil
 }
 
+// GetRate returns the latest rate between base and dest
 func (e *ExchangeRates) GetRate(base string, dest string) (float64, error) {
-	br, ok := e.rates[base]
+	return e.Snapshot().Rate(base, dest)
+}
+
+// SimulatorSource is the source of rates changed by a Simulator
+const SimulatorSource = "simulator"
+
+// CurrencyInfo describes the latest rate held for a currency
+type CurrencyInfo struct {
+	// Code is the ISO 4217 code of the currency
+	Code string
+
+	// Updated is when the rate was last changed
+	Updated time.Time
+
+	// Source is the name of the provider the rate came from, or
+	// SimulatorSource when the rate was last changed by the simulator
+	Source string
+}
+
+// Currencies returns the currencies with a rate in alphabetical order
+func (e *ExchangeRates) Currencies() []CurrencyInfo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	cs := []CurrencyInfo{}
+	for k := range e.rates {
+		cs = append(cs, CurrencyInfo{Code: k, Updated: e.updated[k], Source: e.sources[k]})
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Code < cs[j].Code })
+
+	return cs
+}
+
+// Snapshot returns the latest rates
+func (e *ExchangeRates) Snapshot() *Snapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.snapshots.latest()
+}
+
+// SnapshotByID returns one of the MaxSnapshots most recent snapshots, an
+// ErrSnapshotNotFound error is returned when the snapshot has expired
+func (e *ExchangeRates) SnapshotByID(id string) (*Snapshot, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.snapshots.get(id)
+}
+
+// snapshotLocked records the current rates as the latest snapshot, the caller
+// must hold mu
+func (e *ExchangeRates) snapshotLocked() {
+	e.seq++
+	id := e.epoch + "-" + strconv.Itoa(e.seq)
+
+	e.snapshots.add(NewSnapshot(id, time.Now(), e.rates))
+}
+
+// GetHistoricalRate returns the rate between base and dest published on the
+// given date. When no rates were published that day, weekends and holidays,
+// the most recent earlier rates are used. The date of the rates is returned
+func (e *ExchangeRates) GetHistoricalRate(base string, dest string, date time.Time) (float64, time.Time, error) {
+	published, rates, err := e.history.RatesAt(date)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	br, ok := rates[base]
 	if !ok {
-		return 0, fmt.Errorf("Rate not found for %s", base)
+		return 0, time.Time{}, fmt.Errorf("Rate not found for %s on %s", base, published.Format(DateFormat))
 	}
 
-	dr, ok := e.rates[dest]
+	dr, ok := rates[dest]
 	if !ok {
-		return 0, fmt.Errorf("Rate not found for %s", dest)
+		return 0, time.Time{}, fmt.Errorf("Rate not found for %s on %s", dest, published.Format(DateFormat))
 	}
 
-	return dr / br, nil
+	return dr / br, published, nil
 }
 
-// MonitorRates checks the rates in the ECB API every interval and sends a message to the
-// returned channel when there are changes
-//
-// Note: the ECB API only returns data once a day, this function only simulates the changes
-// in rates for demonstration purposes
-func (e *ExchangeRates) MonitorRates(interval time.Duration) chan struct{} {
-
-	ret := make(chan struct{})
-
-	go func() {
-		ticker := time.NewTicker(interval)
-		for {
-			select {
-			case <-ticker.C:
+// Updates returns a channel which receives a message after the rates change,
+// changes made while the previous message is unread are combined into a
+// single message
+func (e *ExchangeRates) Updates() <-chan struct{} {
+	return e.updates
+}
 
-				for k, v := range e.rates {
+// Loaded returns a channel which is closed once the rates have been loaded
+// from the provider, until then there are no rates
+func (e *ExchangeRates) Loaded() <-chan struct{} {
+	return e.loaded
+}
 
-					change := (rand.Float64() / 10)
-					direction := rand.Intn(1)
+// notify signals a change to the rates without blocking
+func (e *ExchangeRates) notify() {
+	select {
+	case e.updates <- struct{}{}:
+	default:
+	}
+}
 
-					if direction == 0 {
+// Simulate changes the rates with the simulator every interval, for
+// demonstration and integration tests as the ECB only publishes rates once a
+// day
+func (e *ExchangeRates) Simulate(s *Simulator) {
+	e.log.Info("Simulating rate changes", "interval", s.Interval())
 
-						change = 1 - change
-					} else {
+	go func() {
+		ticker := time.NewTicker(s.Interval())
+		defer ticker.Stop()
 
-						change = 1 + change
-					}
-					e.rates[k] = v * change
-				}
-				ret <- struct{}{}
-			}
+		for range ticker.C {
+			e.simulate(s)
 		}
 	}()
-	return ret
-
 }
 
-func (e *ExchangeRates) getRates() error {
+// simulate applies a single tick of the simulator to the rates
+func (e *ExchangeRates) simulate(s *Simulator) {
+	e.mu.Lock()
+	e.rates = s.Next(e.rates)
+	now := time.Now()
+	for k := range e.rates {
+		e.updated[k] = now
+		e.sources[k] = SimulatorSource
+	}
+	e.snapshotLocked()
+	e.mu.Unlock()
+
+	e.notify()
+}
 
-	res, err := http.DefaultClient.Get("https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml")
+// getRates loads the latest rates from the provider, the rates are only
+// changed, and an update announced, when the provider has published a new
+// date or any of the rates differ. Returns true when the rates changed
+func (e *ExchangeRates) getRates() (bool, error) {
+	rs, err := e.provider.Latest()
 	if err != nil {
-		return nil
+		return false, err
+	}
+	defer e.loadedOnce.Do(func() { close(e.loaded) })
+
+	e.mu.Lock()
+	if !e.changedLocked(rs) {
+		e.mu.Unlock()
+		return false, nil
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("Expected status code 200 got %d", res.StatusCode)
+	now := time.Now()
+	for k, v := range rs.Rates {
+		e.rates[k] = v
+		e.updated[k] = now
+		e.sources[k] = e.provider.Name()
 	}
-	defer res.Body.Close()
+	e.published = rs.Date
+	e.snapshotLocked()
+	e.mu.Unlock()
 
-	md := &Cubes{}
-	xml.NewDecoder(res.Body).Decode(&md)
+	e.notify()
 
-	for _, c := range md.CubeData {
+	e.history.Add(rs.Date, rs.Rates)
+
+	return true, nil
+}
 
-		r, err := strconv.ParseFloat(c.Rate, 64)
-		if err != nil {
-			return err
+// changedLocked returns true when the rates differ from the current rates,
+// the caller must hold mu
+func (e *ExchangeRates) changedLocked(rs *RateSnapshot) bool {
+	if !rs.Date.Equal(e.published) {
+		return true
+	}
+
+	for k, v := range rs.Rates {
+		if r, ok := e.rates[k]; !ok || r != v {
+			return true
 		}
-		e.rates[c.Currency] = r
 	}
 
-	e.rates["EUR"] = 1
+	return false
+}
 
-	return nil
+// MonitorRates checks the provider for new rates following the schedule,
+// subscribers are only notified when the rates have changed. The history is
+// loaded after the first successful check when it could not be loaded by
+// NewRates
+func (e *ExchangeRates) MonitorRates(c Clock, s PollSchedule) {
+	go func() {
+		for {
+			e.mu.RLock()
+			published := e.published
+			historyLoaded := e.historyLoaded
+			e.mu.RUnlock()
+
+			now := c.Now()
+			next := s.Next(now, published)
+
+			// retry soon when the rates or the history have never been loaded
+			if published.IsZero() || !historyLoaded {
+				next = now.Add(s.RetryInterval)
+			}
+			e.log.Debug("Waiting to check for new rates", "provider", e.provider.Name(), "next", next)
+
+			<-c.After(next.Sub(now))
+
+			changed, err := e.getRates()
+			if err != nil {
+				e.log.Error("Unable to check for new rates", "provider", e.provider.Name(), "error", err)
+				continue
+			}
+
+			e.log.Info("Checked for new rates", "provider", e.provider.Name(), "changed", changed)
+
+			if !historyLoaded {
+				e.loadHistory()
+			}
+		}
+	}()
 }
 
-type Cubes struct {
-	CubeData []Cube `xml:"Cube>Cube>Cube"`
+// loadHistory seeds the rate history from the provider, the history is only
+// needed for as of conversions so the service runs without it until a later
+// attempt succeeds
+func (e *ExchangeRates) loadHistory() {
+	err := e.getHistory()
+	if err != nil {
+		e.log.Warn("Unable to load historical rates", "provider", e.provider.Name(), "error", err)
+		return
+	}
+
+	e.mu.Lock()
+	e.historyLoaded = true
+	e.mu.Unlock()
 }
 
-type Cube struct {
-	Currency string `xml:"currency,attr"`
-	Rate     string `xml:"rate,attr"`
+// getHistory seeds the rate history when the provider supports it
+func (e *ExchangeRates) getHistory() error {
+	hp, ok := e.provider.(HistoryProvider)
+	if !ok {
+		return nil
+	}
+
+	rss, err := hp.History()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range rss {
+		e.history.Add(rs.Date, rs.Rates)
+	}
+
+	return nil
 }
@@ -2,14 +2,16 @@ package data
 
 import (
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 )
 
 func TestNewRates(t *testing.T) {
 
-	tr, err := NewRates(hclog.Default())
+	tr, err := NewRates(hclog.Default(), NewFileProvider("testdata/eurofxref-daily.xml"))
 
 	if err != nil {
 		t.Fatal(err)
@@ -17,3 +19,238 @@ func TestNewRates(t *testing.T) {
 
 	fmt.Printf("%#v", tr.rates)
 }
+
+func TestNewRatesLoadsHistoryFromProvider(t *testing.T) {
+	tr, err := NewRates(hclog.Default(), NewFileProvider("testdata/eurofxref-hist.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// latest rates come from the most recent day in the file
+	if tr.rates["USD"] != 1.1842 {
+		t.Fatalf("expected latest USD rate 1.1842 got %f", tr.rates["USD"])
+	}
+
+	_, _, err = tr.GetHistoricalRate("EUR", "USD", date(t, "2026-01-29"))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeClock is a Clock which only moves when the test fires a wait
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits chan fakeWait
+}
+
+type fakeWait struct {
+	d  time.Duration
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waits: make(chan fakeWait)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	f.waits <- fakeWait{d, ch}
+	return ch
+}
+
+// wait waits for the clock to be waited on and checks the wait ends at the
+// expected time. The waiter is blocked until fire is called
+func (f *fakeClock) wait(t *testing.T, expected time.Time) fakeWait {
+	var w fakeWait
+	select {
+	case w = <-f.waits:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the clock to be waited on")
+	}
+
+	if end := f.Now().Add(w.d); !end.Equal(expected) {
+		t.Fatalf("expected to wait until %s got %s", expected, end)
+	}
+
+	return w
+}
+
+// fire moves the clock to the end of the wait and releases the waiter
+func (f *fakeClock) fire(w fakeWait) {
+	f.mu.Lock()
+	f.now = f.now.Add(w.d)
+	now := f.now
+	f.mu.Unlock()
+
+	w.ch <- now
+}
+
+// updated returns true when an update has been announced
+func updated(er *ExchangeRates) bool {
+	select {
+	case <-er.Updates():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestMonitorRatesPollsECBOnSchedule(t *testing.T) {
+	ecb := &ecbStandIn{}
+	ecb.publish("2026-10-16", "1.1842")
+
+	er, err := NewRates(hclog.NewNullLogger(), newTestECBProvider(t, ecb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated(er)
+
+	berlin := ECBSchedule.Location
+	clock := newFakeClock(time.Date(2026, time.October, 19, 10, 0, 0, 0, berlin))
+	er.MonitorRates(clock, ECBSchedule)
+
+	// the rates are late so the feed is unchanged at the publication time
+	w := clock.wait(t, time.Date(2026, time.October, 19, 16, 0, 0, 0, berlin))
+	clock.fire(w)
+
+	// the next wait starts once the check has finished
+	w = clock.wait(t, time.Date(2026, time.October, 19, 16, 15, 0, 0, berlin))
+	if updated(er) {
+		t.Fatal("expected no update when the feed has not changed")
+	}
+
+	ecb.publish("2026-10-19", "1.19")
+	clock.fire(w)
+
+	clock.wait(t, time.Date(2026, time.October, 20, 16, 0, 0, 0, berlin))
+	if !updated(er) {
+		t.Fatal("expected an update when new rates are published")
+	}
+
+	r, err := er.GetRate("EUR", "USD")
+	if err != nil || r != 1.19 {
+		t.Fatalf("expected USD rate 1.19 got %f, %v", r, err)
+	}
+
+	// the history shares the feed so it and the first check are not modified
+	requests, notModified := ecb.counts()
+	if requests != 4 || notModified != 2 {
+		t.Fatalf("expected 4 requests with 2 not modified got %d and %d", requests, notModified)
+	}
+}
+
+func TestGetRatesOnlyAnnouncesChanges(t *testing.T) {
+	ecb := &ecbStandIn{}
+	ecb.publish("2026-10-16", "1.1842")
+
+	er, err := NewRates(hclog.NewNullLogger(), newTestECBProvider(t, ecb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated(er)
+	before := er.Snapshot().ID
+
+	// new response with the same date and rates
+	ecb.mu.Lock()
+	ecb.etag = `"changed"`
+	ecb.mu.Unlock()
+
+	changed, err := er.getRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || updated(er) || er.Snapshot().ID != before {
+		t.Fatal("expected no update when the rates are the same")
+	}
+
+	// a correction to a rate on the same date is announced
+	ecb.publish("2026-10-16", "1.1843")
+	changed, err = er.getRates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed || !updated(er) {
+		t.Fatal("expected an update when a rate changes")
+	}
+}
+
+// flakyProvider fails until it is recovered, then returns a single day of
+// rates as both the latest rates and the history
+type flakyProvider struct {
+	mu        sync.Mutex
+	recovered bool
+}
+
+func (f *flakyProvider) Name() string { return "flaky" }
+
+func (f *flakyProvider) recover() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.recovered = true
+}
+
+func (f *flakyProvider) snapshot() (*RateSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.recovered {
+		return nil, fmt.Errorf("provider unavailable")
+	}
+
+	return &RateSnapshot{Date: time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), Rates: map[string]float64{"EUR": 1, "USD": 1.18}}, nil
+}
+
+func (f *flakyProvider) Latest() (*RateSnapshot, error) {
+	return f.snapshot()
+}
+
+func (f *flakyProvider) History() ([]*RateSnapshot, error) {
+	rs, err := f.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return []*RateSnapshot{rs}, nil
+}
+
+func TestMonitorRatesLoadsHistoryAfterFailedStart(t *testing.T) {
+	fp := &flakyProvider{}
+
+	er, err := NewRates(hclog.NewNullLogger(), fp)
+	if err == nil {
+		t.Fatal("expected an error when the provider is unavailable")
+	}
+
+	berlin := ECBSchedule.Location
+	start := time.Date(2026, time.October, 19, 10, 0, 0, 0, berlin)
+	clock := newFakeClock(start)
+	er.MonitorRates(clock, ECBSchedule)
+
+	// still unavailable so the check is retried
+	w := clock.wait(t, start.Add(ECBSchedule.RetryInterval))
+	clock.fire(w)
+	w = clock.wait(t, start.Add(2*ECBSchedule.RetryInterval))
+
+	fp.recover()
+	clock.fire(w)
+
+	// the next wait starts once the rates and history have been loaded
+	clock.wait(t, time.Date(2026, time.October, 19, 16, 0, 0, 0, berlin))
+
+	_, published, err := er.GetHistoricalRate("EUR", "USD", date(t, "2026-10-16"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !published.Equal(date(t, "2026-10-16")) {
+		t.Fatalf("expected rates published on 2026-10-16 got %s", published)
+	}
+}
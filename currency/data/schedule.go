@@ -0,0 +1,80 @@
+package data
+
+import "time"
+
+// PollSchedule decides when to check a provider for newly published rates.
+// Rates are expected once a day, Monday to Friday, at the publication time
+type PollSchedule struct {
+	// Location the publication time is in
+	Location *time.Location
+
+	// PublishedAt is the time after midnight the rates are published
+	PublishedAt time.Duration
+
+	// RetryInterval is the time between checks when the rates are late
+	RetryInterval time.Duration
+
+	// RetryFor is how long after the publication time to keep checking for
+	// late rates, after which the next business day is waited for
+	RetryFor time.Duration
+}
+
+// ECBSchedule follows the publication of the ECB reference rates, around
+// 16:00 CET on business days
+var ECBSchedule = PollSchedule{
+	Location:      ecbLocation(),
+	PublishedAt:   16 * time.Hour,
+	RetryInterval: 15 * time.Minute,
+	RetryFor:      3 * time.Hour,
+}
+
+// ecbLocation returns the time zone of Frankfurt, when the time zone database
+// is not available CET is used without daylight saving time
+func ecbLocation() *time.Location {
+	l, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		return time.FixedZone("CET", 60*60)
+	}
+
+	return l
+}
+
+// Next returns the time of the next check after now given the date of the
+// latest rates. While the rates for today are missing they are checked for
+// every RetryInterval from the publication time until RetryFor has passed
+func (s PollSchedule) Next(now time.Time, latest time.Time) time.Time {
+	now = now.In(s.Location)
+
+	start := s.publishedOn(now)
+	if isBusinessDay(now) && latest.Format(DateFormat) < now.Format(DateFormat) {
+		if now.Before(start) {
+			return start
+		}
+
+		if now.Before(start.Add(s.RetryFor)) {
+			return now.Add(s.RetryInterval)
+		}
+	}
+
+	// wait for the publication on the next business day
+	for {
+		start = s.publishedOn(start.AddDate(0, 0, 1))
+		if isBusinessDay(start) {
+			return start
+		}
+	}
+}
+
+// publishedOn returns the publication time on the day of t, the time is
+// added as wall clock time so it is not moved by daylight saving changes
+func (s PollSchedule) publishedOn(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, int(s.PublishedAt), s.Location)
+}
+
+// isBusinessDay returns true for Monday to Friday, public holidays are not
+// taken into account and are treated as late rates
+func isBusinessDay(t time.Time) bool {
+	wd := t.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+}
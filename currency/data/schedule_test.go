@@ -0,0 +1,50 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestECBScheduleNext(t *testing.T) {
+	berlin := ECBSchedule.Location
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, time.October, day, hour, min, 0, 0, berlin)
+	}
+	published := func(day int) time.Time {
+		return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		latest time.Time
+		next   time.Time
+	}{
+		{"before publication", at(19, 10, 0), published(16), at(19, 16, 0)},
+		{"rates are late", at(19, 16, 30), published(16), at(19, 16, 45)},
+		{"rates never published", at(19, 19, 30), published(16), at(20, 16, 0)},
+		{"already published today", at(19, 16, 30), published(19), at(20, 16, 0)},
+		{"friday waits for monday", at(23, 17, 0), published(23), at(26, 16, 0)},
+		{"weekend waits for monday", at(24, 10, 0), published(23), at(26, 16, 0)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next := ECBSchedule.Next(tc.now, tc.latest)
+			if !next.Equal(tc.next) {
+				t.Fatalf("expected %s got %s", tc.next, next)
+			}
+		})
+	}
+}
+
+func TestECBScheduleFollowsDaylightSaving(t *testing.T) {
+	// summer time ends on 25 October 2026, 16:00 CET is 15:00 UTC
+	now := time.Date(2026, time.October, 23, 17, 0, 0, 0, ECBSchedule.Location)
+	latest := time.Date(2026, time.October, 23, 0, 0, 0, 0, time.UTC)
+
+	next := ECBSchedule.Next(now, latest).UTC()
+	if next != time.Date(2026, time.October, 26, 15, 0, 0, 0, time.UTC) {
+		t.Fatalf("expected 15:00 UTC got %s", next)
+	}
+}
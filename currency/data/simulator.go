@@ -0,0 +1,90 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// SimulationConfig configures the random changes made to the rates when
+// simulating a live market
+type SimulationConfig struct {
+	// Seed for the random changes, the same seed and starting rates always
+	// produce the same sequence of rates
+	Seed int64
+
+	// Volatility is the largest random change made to a rate each tick as a
+	// fraction of the rate, e.g. 0.01 for 1%
+	Volatility float64
+
+	// Drift is added to the change made to every rate each tick as a
+	// fraction of the rate, a positive drift weakens the euro
+	Drift float64
+
+	// Interval between changes to the rates
+	Interval time.Duration
+}
+
+// DefaultSimulationConfig changes the rates by up to 1% every 5 seconds
+var DefaultSimulationConfig = SimulationConfig{
+	Seed:       1,
+	Volatility: 0.01,
+	Interval:   5 * time.Second,
+}
+
+// Simulator makes random changes to EUR based rates, it is not safe for
+// concurrent use
+type Simulator struct {
+	config SimulationConfig
+	rand   *rand.Rand
+}
+
+// NewSimulator creates a Simulator with the given config
+func NewSimulator(c SimulationConfig) (*Simulator, error) {
+	if c.Interval <= 0 {
+		return nil, fmt.Errorf("Invalid simulation interval %s, interval must be positive", c.Interval)
+	}
+
+	if c.Volatility < 0 {
+		return nil, fmt.Errorf("Invalid simulation volatility %g, volatility can not be negative", c.Volatility)
+	}
+
+	// the largest fall must leave the rate positive
+	if c.Volatility+math.Abs(c.Drift) >= 1 {
+		return nil, fmt.Errorf("Invalid simulation volatility %g and drift %g, together they must be less than 1", c.Volatility, c.Drift)
+	}
+
+	return &Simulator{config: c, rand: rand.New(rand.NewSource(c.Seed))}, nil
+}
+
+// Interval returns the time between changes to the rates
+func (s *Simulator) Interval() time.Duration {
+	return s.config.Interval
+}
+
+// Next returns a copy of the rates after one tick, the EUR base rate never
+// changes. Rates are changed in order of currency code so the sequence only
+// depends on the seed and the starting rates
+func (s *Simulator) Next(rates map[string]float64) map[string]float64 {
+	codes := []string{}
+	for k := range rates {
+		codes = append(codes, k)
+	}
+	sort.Strings(codes)
+
+	next := map[string]float64{}
+	for _, k := range codes {
+		if k == "EUR" {
+			next[k] = rates[k]
+			continue
+		}
+
+		// uniform change between -Volatility and +Volatility around the drift
+		change := s.config.Drift + s.config.Volatility*(2*s.rand.Float64()-1)
+		next[k] = rates[k] * (1 + change)
+	}
+
+	return next
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func newTestSimulator(t *testing.T, c SimulationConfig) *Simulator {
+	s, err := NewSimulator(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestSimulatorIsReproducible(t *testing.T) {
+	rates := map[string]float64{"EUR": 1, "USD": 1.18, "GBP": 0.86}
+
+	a := newTestSimulator(t, DefaultSimulationConfig)
+	b := newTestSimulator(t, DefaultSimulationConfig)
+
+	ra, rb := rates, rates
+	for i := 0; i < 10; i++ {
+		ra, rb = a.Next(ra), b.Next(rb)
+		if !reflect.DeepEqual(ra, rb) {
+			t.Fatalf("expected the same rates for the same seed at tick %d got %v and %v", i, ra, rb)
+		}
+	}
+
+	if ra["EUR"] != 1 {
+		t.Fatalf("expected the EUR base rate to stay 1 got %f", ra["EUR"])
+	}
+
+	if ra["USD"] == rates["USD"] {
+		t.Fatal("expected the USD rate to change")
+	}
+}
+
+func TestSimulatorRatesRiseAndFall(t *testing.T) {
+	s := newTestSimulator(t, DefaultSimulationConfig)
+
+	rose, fell := false, false
+	r := map[string]float64{"USD": 1.18}
+	for i := 0; i < 20; i++ {
+		n := s.Next(r)
+		rose = rose || n["USD"] > r["USD"]
+		fell = fell || n["USD"] < r["USD"]
+		r = n
+	}
+
+	if !rose || !fell {
+		t.Fatalf("expected rates to rise and fall, rose: %t, fell: %t", rose, fell)
+	}
+}
+
+func TestSimulatorStaysWithinVolatilityAndDrift(t *testing.T) {
+	s := newTestSimulator(t, SimulationConfig{Seed: 7, Volatility: 0.01, Drift: 0.02, Interval: time.Second})
+
+	r := map[string]float64{"USD": 1}
+	for i := 0; i < 100; i++ {
+		n := s.Next(r)
+		change := n["USD"]/r["USD"] - 1
+		if change < 0.01-1e-9 || change > 0.03+1e-9 {
+			t.Fatalf("expected change between 1%% and 3%% got %f", change)
+		}
+		r = n
+	}
+}
+
+func TestNewSimulatorInvalidConfig(t *testing.T) {
+	for _, c := range []SimulationConfig{
+		{Volatility: 0.01},
+		{Volatility: -0.01, Interval: time.Second},
+		{Volatility: 0.5, Drift: -0.5, Interval: time.Second},
+	} {
+		if _, err := NewSimulator(c); err == nil {
+			t.Fatalf("expected error for %+v", c)
+		}
+	}
+}
+
+func TestSimulateCreatesSnapshotAndNotifies(t *testing.T) {
+	er, err := NewRates(hclog.NewNullLogger(), NewStaticProvider(map[string]float64{"USD": 1.18}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// drain the update from loading the rates
+	<-er.Updates()
+	before := er.Snapshot()
+
+	er.simulate(newTestSimulator(t, DefaultSimulationConfig))
+
+	select {
+	case <-er.Updates():
+	default:
+		t.Fatal("expected an update after simulating")
+	}
+
+	after := er.Snapshot()
+	if after.ID == before.ID {
+		t.Fatal("expected a new snapshot")
+	}
+
+	r, _ := after.Rate("EUR", "USD")
+	if r == 1.18 {
+		t.Fatal("expected the USD rate to change")
+	}
+
+	for _, ci := range er.Currencies() {
+		if ci.Source != SimulatorSource {
+			t.Fatalf("expected source %s got %s", SimulatorSource, ci.Source)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxSnapshots is the number of recent snapshots kept by ExchangeRates so
+// clients can continue to price with rates which have since been updated
+const MaxSnapshots = 32
+
+// ErrSnapshotNotFound is an error raised when a snapshot has expired or never existed
+var ErrSnapshotNotFound = fmt.Errorf("Snapshot not found, it may have expired")
+
+// Snapshot is an immutable set of the latest rates at a point in time. Every
+// update to the rates creates a new snapshot with a new ID, so calls which
+// request the same ID are priced with the same rates
+type Snapshot struct {
+	ID      string
+	Created time.Time
+
+	rates map[string]float64
+}
+
+// NewSnapshot creates a snapshot with a copy of the given EUR based rates
+func NewSnapshot(id string, created time.Time, rates map[string]float64) *Snapshot {
+	s := &Snapshot{ID: id, Created: created, rates: map[string]float64{}}
+	for k, v := range rates {
+		s.rates[k] = v
+	}
+
+	return s
+}
+
+// Rate returns the rate between base and dest
+func (s *Snapshot) Rate(base string, dest string) (float64, error) {
+	br, ok := s.rates[base]
+	if !ok {
+		return 0, fmt.Errorf("Rate not found for %s", base)
+	}
+
+	dr, ok := s.rates[dest]
+	if !ok {
+		return 0, fmt.Errorf("Rate not found for %s", dest)
+	}
+
+	return dr / br, nil
+}
+
+// Currencies returns the currencies in the snapshot in alphabetical order
+func (s *Snapshot) Currencies() []string {
+	cs := []string{}
+	for k := range s.rates {
+		cs = append(cs, k)
+	}
+	sort.Strings(cs)
+
+	return cs
+}
+
+// snapshotRing keeps the most recent snapshots, oldest first
+type snapshotRing struct {
+	snapshots []*Snapshot
+	size      int
+}
+
+func newSnapshotRing(size int) *snapshotRing {
+	return &snapshotRing{size: size}
+}
+
+// add makes the snapshot the latest, dropping the oldest when full
+func (r *snapshotRing) add(s *Snapshot) {
+	r.snapshots = append(r.snapshots, s)
+	if len(r.snapshots) > r.size {
+		r.snapshots = r.snapshots[len(r.snapshots)-r.size:]
+	}
+}
+
+// latest returns the most recent snapshot, nil when empty
+func (r *snapshotRing) latest() *Snapshot {
+	if len(r.snapshots) == 0 {
+		return nil
+	}
+
+	return r.snapshots[len(r.snapshots)-1]
+}
+
+// get returns the snapshot with the given id
+func (r *snapshotRing) get(id string) (*Snapshot, error) {
+	for i := len(r.snapshots) - 1; i >= 0; i-- {
+		if r.snapshots[i].ID == id {
+			return r.snapshots[i], nil
+		}
+	}
+
+	return nil, ErrSnapshotNotFound
+}
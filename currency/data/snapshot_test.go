@@ -0,0 +1,70 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestSnapshotRingExpiresOldest(t *testing.T) {
+	r := newSnapshotRing(2)
+	for i := 1; i <= 3; i++ {
+		r.add(NewSnapshot(fmt.Sprint(i), time.Now(), map[string]float64{"EUR": 1}))
+	}
+
+	if r.latest().ID != "3" {
+		t.Fatalf("expected latest snapshot 3 got %s", r.latest().ID)
+	}
+
+	if _, err := r.get("1"); err != ErrSnapshotNotFound {
+		t.Fatalf("expected ErrSnapshotNotFound got %v", err)
+	}
+
+	if _, err := r.get("2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotIsNotChangedByUpdates(t *testing.T) {
+	rates := map[string]float64{"EUR": 1, "USD": 1.18}
+	s := NewSnapshot("1", time.Now(), rates)
+
+	rates["USD"] = 2
+	r, err := s.Rate("EUR", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != 1.18 {
+		t.Fatalf("expected rate 1.18 got %f", r)
+	}
+}
+
+func TestExchangeRatesSnapshots(t *testing.T) {
+	tr, err := NewRates(hclog.NewNullLogger(), NewStaticProvider(map[string]float64{"USD": 1.18}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := tr.Snapshot()
+
+	tr.mu.Lock()
+	tr.rates["USD"] = 1.2
+	tr.snapshotLocked()
+	tr.mu.Unlock()
+
+	if tr.Snapshot().ID == first.ID {
+		t.Fatal("expected a new snapshot after the rates changed")
+	}
+
+	s, err := tr.SnapshotByID(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := s.Rate("EUR", "USD")
+	if r != 1.18 {
+		t.Fatalf("expected rate 1.18 from the earlier snapshot got %f", r)
+	}
+}
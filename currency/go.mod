@@ -3,11 +3,23 @@ module github.com/JamieBShaw/golang-mux-rest-api/currency
 go 1.14
 
 require (
+	github.com/JamieBShaw/golang-mux-rest-api/config v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/tracing v0.0.0
 	github.com/fullstorydev/grpcurl v1.5.0 // indirect
 	github.com/golang/protobuf v1.4.2
+	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
 	github.com/hashicorp/go-hclog v0.14.1
 	github.com/nicholasjackson/building-microservices-youtube/currency v0.0.0-20200615074401-130c1df925c8
+	github.com/prometheus/client_golang v1.7.1
+	github.com/shopspring/decimal v1.2.0
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc v0.11.0
+	go.opentelemetry.io/otel v0.11.0
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.31.0
 	google.golang.org/grpc/cmd/protoc-gen-go-grpc v0.0.0-20200812184716-7d8921505e1b // indirect
 	google.golang.org/protobuf v1.25.0
 )
+
+replace github.com/JamieBShaw/golang-mux-rest-api/config => ../config
+
+replace github.com/JamieBShaw/golang-mux-rest-api/tracing => ../tracing
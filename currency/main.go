@@ -1,31 +1,109 @@
 package main
 
 import (
+	"flag"
 	"net"
+	"net/http"
 	"os"
 
+	"github.com/JamieBShaw/golang-mux-rest-api/config"
 	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
 	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/security"
 	"github.com/JamieBShaw/golang-mux-rest-api/currency/server"
+	"github.com/JamieBShaw/golang-mux-rest-api/tracing"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	grpctrace "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc"
+	"go.opentelemetry.io/otel/api/global"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+// serviceName identifies the logs, flags and spans of this service
+const serviceName = "currency"
+
 func main() {
+	cfg := &Config{}
+	err := config.Load(serviceName, "CURRENCY", cfg, os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		hclog.Default().Error("Unable to load configuration", "error", err)
+		os.Exit(2)
+	}
 
 	// Setting default logger
-	log := hclog.Default()
+	log := hclog.New(&hclog.LoggerOptions{
+		Name:  serviceName,
+		Level: hclog.LevelFromString(cfg.LogLevel),
+	})
+	log.Info("Loaded configuration", config.Values(cfg)...)
+
+	closeTracing, err := tracing.Setup(serviceName, cfg.TraceOutput)
+	if err != nil {
+		log.Error("Unable to set up tracing", "output", cfg.TraceOutput, "error", err)
+		os.Exit(1)
+	}
+	defer closeTracing()
 
-	rates, err := data.NewRates(log)
+	rp, err := data.NewRateProvider(data.ProviderConfig{Type: cfg.RateProvider, Path: cfg.RateFile, Rates: cfg.StaticRates})
 	if err != nil {
+		log.Error("Unable to create rate provider", "error", err)
+		os.Exit(1)
+	}
+
+	// without rates the server reports NOT_SERVING until they can be loaded
+	rates, err := data.NewRates(log, rp)
+	if err != nil && cfg.Simulate {
 		log.Error("Unable to generate rates", "error", err)
 		os.Exit(1)
+	}
+	if err != nil {
+		log.Error("Unable to generate rates, retrying", "error", err)
+	}
+
+	if cfg.Simulate {
+		sim, err := data.NewSimulator(cfg.simulation())
+		if err != nil {
+			log.Error("Unable to create rate simulator", "error", err)
+			os.Exit(1)
+		}
+
+		rates.Simulate(sim)
+	} else {
+		rates.MonitorRates(data.RealClock, data.ECBSchedule)
+	}
+
+	// Generate default grpc server, tracing calls as children of the caller's span
+	tracer := global.Tracer(serviceName)
+	unary := []grpc.UnaryServerInterceptor{grpctrace.UnaryServerInterceptor(tracer), grpc_prometheus.UnaryServerInterceptor}
+	stream := []grpc.StreamServerInterceptor{grpctrace.StreamServerInterceptor(tracer), grpc_prometheus.StreamServerInterceptor}
 
+	// rejected calls are still traced and counted
+	if len(cfg.AuthTokens) > 0 {
+		ta := security.NewTokenAuth(cfg.AuthTokens, log)
+		unary = append(unary, ta.UnaryServerInterceptor)
+		stream = append(stream, ta.StreamServerInterceptor)
 	}
 
-	// Generate default grpc server
-	gs := grpc.NewServer()
+	opts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...)}
+	if cfg.TLSCertFile != "" {
+		tc, err := security.ServerTLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+		if err != nil {
+			log.Error("Unable to configure TLS", "error", err)
+			os.Exit(1)
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tc)))
+		log.Info("Serving gRPC over TLS", "mutual_tls", cfg.TLSClientCAFile != "", "token_auth", len(cfg.AuthTokens) > 0)
+	}
+
+	gs := grpc.NewServer(opts...)
 
 	// Setting up our currency server
 	cs := server.NewCurrency(rates, log)
@@ -33,10 +111,26 @@ func main() {
 	//  Registering Currency server to grpc server
 	protos.RegisterCurrencyServer(gs, cs)
 
+	healthpb.RegisterHealthServer(gs, server.NewHealth(rates))
+
 	reflection.Register(gs)
 
+	// expose the gRPC and rate metrics for Prometheus
+	grpc_prometheus.EnableHandlingTimeHistogram()
+	grpc_prometheus.Register(gs)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		err := http.ListenAndServe(cfg.MetricsAddr, mux)
+		if err != nil {
+			log.Error("Unable to serve metrics", "error", err)
+			os.Exit(1)
+		}
+	}()
+
 	// Setting port location
-	l, err := net.Listen("tcp", ":9092")
+	l, err := net.Listen("tcp", cfg.ListenAddr)
 
 	if err != nil {
 		log.Error("Unable to listen", "error", err)
@@ -4,10 +4,15 @@
 // 	protoc        v3.12.3
 // source: currency.proto
 
+// v2 identifies currencies by ISO 4217 code strings instead of the v1
+// Currencies enum. The field numbers used by the enum fields are reserved so
+// v1 messages are never misread
+
 package currencypb
 
 import (
 	proto "github.com/golang/protobuf/proto"
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -25,143 +30,60 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
-type Currencies int32
+type SubscriptionAck_Action int32
 
 const (
-	Currencies_EUR Currencies = 0
-	Currencies_USD Currencies = 1
-	Currencies_JPY Currencies = 2
-	Currencies_BGN Currencies = 3
-	Currencies_CZK Currencies = 4
-	Currencies_DKK Currencies = 5
-	Currencies_GBP Currencies = 6
-	Currencies_HUF Currencies = 7
-	Currencies_PLN Currencies = 8
-	Currencies_RON Currencies = 9
-	Currencies_SEK Currencies = 10
-	Currencies_CHF Currencies = 11
-	Currencies_ISK Currencies = 12
-	Currencies_NOK Currencies = 13
-	Currencies_HRK Currencies = 14
-	Currencies_RUB Currencies = 15
-	Currencies_TRY Currencies = 16
-	Currencies_AUD Currencies = 17
-	Currencies_BRL Currencies = 18
-	Currencies_CAD Currencies = 19
-	Currencies_CNY Currencies = 20
-	Currencies_HKD Currencies = 21
-	Currencies_IDR Currencies = 22
-	Currencies_ILS Currencies = 23
-	Currencies_INR Currencies = 24
-	Currencies_KRW Currencies = 25
-	Currencies_MXN Currencies = 26
-	Currencies_MYR Currencies = 27
-	Currencies_NZD Currencies = 28
-	Currencies_PHP Currencies = 29
-	Currencies_SGD Currencies = 30
-	Currencies_THB Currencies = 31
-	Currencies_ZAR Currencies = 32
+	// the pair was added to the subscription
+	SubscriptionAck_SUBSCRIBED SubscriptionAck_Action = 0
+	// the pair was already in the subscription, nothing changed
+	SubscriptionAck_ALREADY_SUBSCRIBED SubscriptionAck_Action = 1
+	// the pair was removed from the subscription
+	SubscriptionAck_UNSUBSCRIBED SubscriptionAck_Action = 2
+	// the pair was not in the subscription, nothing changed
+	SubscriptionAck_NOT_SUBSCRIBED SubscriptionAck_Action = 3
 )
 
-// Enum value maps for Currencies.
+// Enum value maps for SubscriptionAck_Action.
 var (
-	Currencies_name = map[int32]string{
-		0:  "EUR",
-		1:  "USD",
-		2:  "JPY",
-		3:  "BGN",
-		4:  "CZK",
-		5:  "DKK",
-		6:  "GBP",
-		7:  "HUF",
-		8:  "PLN",
-		9:  "RON",
-		10: "SEK",
-		11: "CHF",
-		12: "ISK",
-		13: "NOK",
-		14: "HRK",
-		15: "RUB",
-		16: "TRY",
-		17: "AUD",
-		18: "BRL",
-		19: "CAD",
-		20: "CNY",
-		21: "HKD",
-		22: "IDR",
-		23: "ILS",
-		24: "INR",
-		25: "KRW",
-		26: "MXN",
-		27: "MYR",
-		28: "NZD",
-		29: "PHP",
-		30: "SGD",
-		31: "THB",
-		32: "ZAR",
-	}
-	Currencies_value = map[string]int32{
-		"EUR": 0,
-		"USD": 1,
-		"JPY": 2,
-		"BGN": 3,
-		"CZK": 4,
-		"DKK": 5,
-		"GBP": 6,
-		"HUF": 7,
-		"PLN": 8,
-		"RON": 9,
-		"SEK": 10,
-		"CHF": 11,
-		"ISK": 12,
-		"NOK": 13,
-		"HRK": 14,
-		"RUB": 15,
-		"TRY": 16,
-		"AUD": 17,
-		"BRL": 18,
-		"CAD": 19,
-		"CNY": 20,
-		"HKD": 21,
-		"IDR": 22,
-		"ILS": 23,
-		"INR": 24,
-		"KRW": 25,
-		"MXN": 26,
-		"MYR": 27,
-		"NZD": 28,
-		"PHP": 29,
-		"SGD": 30,
-		"THB": 31,
-		"ZAR": 32,
+	SubscriptionAck_Action_name = map[int32]string{
+		0: "SUBSCRIBED",
+		1: "ALREADY_SUBSCRIBED",
+		2: "UNSUBSCRIBED",
+		3: "NOT_SUBSCRIBED",
+	}
+	SubscriptionAck_Action_value = map[string]int32{
+		"SUBSCRIBED":         0,
+		"ALREADY_SUBSCRIBED": 1,
+		"UNSUBSCRIBED":       2,
+		"NOT_SUBSCRIBED":     3,
 	}
 )
 
-func (x Currencies) Enum() *Currencies {
-	p := new(Currencies)
+func (x SubscriptionAck_Action) Enum() *SubscriptionAck_Action {
+	p := new(SubscriptionAck_Action)
 	*p = x
 	return p
 }
 
-func (x Currencies) String() string {
+func (x SubscriptionAck_Action) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Currencies) Descriptor() protoreflect.EnumDescriptor {
+func (SubscriptionAck_Action) Descriptor() protoreflect.EnumDescriptor {
 	return file_currency_proto_enumTypes[0].Descriptor()
 }
 
-func (Currencies) Type() protoreflect.EnumType {
+func (SubscriptionAck_Action) Type() protoreflect.EnumType {
 	return &file_currency_proto_enumTypes[0]
 }
 
-func (x Currencies) Number() protoreflect.EnumNumber {
+func (x SubscriptionAck_Action) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use Currencies.Descriptor instead.
-func (Currencies) EnumDescriptor() ([]byte, []int) {
-	return file_currency_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use SubscriptionAck_Action.Descriptor instead.
+func (SubscriptionAck_Action) EnumDescriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{9, 0}
 }
 
 type RateRequest struct {
@@ -169,8 +91,8 @@ type RateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Base        Currencies `protobuf:"varint,1,opt,name=Base,proto3,enum=currency.Currencies" json:"Base,omitempty"`
-	Destination Currencies `protobuf:"varint,2,opt,name=Destination,proto3,enum=currency.Currencies" json:"Destination,omitempty"`
+	Base        string `protobuf:"bytes,3,opt,name=Base,proto3" json:"Base,omitempty"`
+	Destination string `protobuf:"bytes,4,opt,name=Destination,proto3" json:"Destination,omitempty"`
 }
 
 func (x *RateRequest) Reset() {
@@ -205,18 +127,18 @@ func (*RateRequest) Descriptor() ([]byte, []int) {
 	return file_currency_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *RateRequest) GetBase() Currencies {
+func (x *RateRequest) GetBase() string {
 	if x != nil {
 		return x.Base
 	}
-	return Currencies_EUR
+	return ""
 }
 
-func (x *RateRequest) GetDestination() Currencies {
+func (x *RateRequest) GetDestination() string {
 	if x != nil {
 		return x.Destination
 	}
-	return Currencies_EUR
+	return ""
 }
 
 type RateResponse struct {
@@ -224,9 +146,11 @@ type RateResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Base        Currencies `protobuf:"varint,1,opt,name=Base,proto3,enum=currency.Currencies" json:"Base,omitempty"`
-	Destination Currencies `protobuf:"varint,2,opt,name=Destination,proto3,enum=currency.Currencies" json:"Destination,omitempty"`
-	Rate        float64    `protobuf:"fixed64,3,opt,name=Rate,proto3" json:"Rate,omitempty"`
+	Rate float64 `protobuf:"fixed64,3,opt,name=Rate,proto3" json:"Rate,omitempty"`
+	// Snapshot the rate was taken from
+	SnapshotID  string `protobuf:"bytes,4,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
+	Base        string `protobuf:"bytes,5,opt,name=Base,proto3" json:"Base,omitempty"`
+	Destination string `protobuf:"bytes,6,opt,name=Destination,proto3" json:"Destination,omitempty"`
 }
 
 func (x *RateResponse) Reset() {
@@ -261,145 +185,1422 @@ func (*RateResponse) Descriptor() ([]byte, []int) {
 	return file_currency_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *RateResponse) GetBase() Currencies {
+func (x *RateResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *RateResponse) GetSnapshotID() string {
+	if x != nil {
+		return x.SnapshotID
+	}
+	return ""
+}
+
+func (x *RateResponse) GetBase() string {
 	if x != nil {
 		return x.Base
 	}
-	return Currencies_EUR
+	return ""
 }
 
-func (x *RateResponse) GetDestination() Currencies {
+func (x *RateResponse) GetDestination() string {
 	if x != nil {
 		return x.Destination
 	}
-	return Currencies_EUR
+	return ""
 }
 
-func (x *RateResponse) GetRate() float64 {
+type RatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Snapshot to take the rates from, when empty the latest rates are used.
+	// Only recent snapshots are kept, a NotFound error is returned for a
+	// snapshot which has expired
+	SnapshotID   string   `protobuf:"bytes,3,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
+	Base         string   `protobuf:"bytes,4,opt,name=Base,proto3" json:"Base,omitempty"`
+	Destinations []string `protobuf:"bytes,5,rep,name=Destinations,proto3" json:"Destinations,omitempty"`
+}
+
+func (x *RatesRequest) Reset() {
+	*x = RatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatesRequest) ProtoMessage() {}
+
+func (x *RatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatesRequest.ProtoReflect.Descriptor instead.
+func (*RatesRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RatesRequest) GetSnapshotID() string {
 	if x != nil {
-		return x.Rate
+		return x.SnapshotID
 	}
-	return 0
+	return ""
 }
 
-var File_currency_proto protoreflect.FileDescriptor
+func (x *RatesRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
 
-var file_currency_proto_rawDesc = []byte{
-	0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x6f, 0x0a, 0x0b, 0x52, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x42, 0x61, 0x73,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x63, 0x79, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x04, 0x42,
-	0x61, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65,
-	0x6e, 0x63, 0x79, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x0b,
-	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x0c,
-	0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04,
-	0x42, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
-	0x52, 0x04, 0x42, 0x61, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65,
-	0x73, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
-	0x0a, 0x04, 0x52, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x52, 0x61,
-	0x74, 0x65, 0x2a, 0xb5, 0x02, 0x0a, 0x0a, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65,
-	0x73, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x55, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x53,
-	0x44, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x50, 0x59, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03,
-	0x42, 0x47, 0x4e, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x5a, 0x4b, 0x10, 0x04, 0x12, 0x07,
-	0x0a, 0x03, 0x44, 0x4b, 0x4b, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x42, 0x50, 0x10, 0x06,
-	0x12, 0x07, 0x0a, 0x03, 0x48, 0x55, 0x46, 0x10, 0x07, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4c, 0x4e,
-	0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x4f, 0x4e, 0x10, 0x09, 0x12, 0x07, 0x0a, 0x03, 0x53,
-	0x45, 0x4b, 0x10, 0x0a, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x48, 0x46, 0x10, 0x0b, 0x12, 0x07, 0x0a,
-	0x03, 0x49, 0x53, 0x4b, 0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f, 0x4b, 0x10, 0x0d, 0x12,
-	0x07, 0x0a, 0x03, 0x48, 0x52, 0x4b, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x55, 0x42, 0x10,
-	0x0f, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x52, 0x59, 0x10, 0x10, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x55,
-	0x44, 0x10, 0x11, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x52, 0x4c, 0x10, 0x12, 0x12, 0x07, 0x0a, 0x03,
-	0x43, 0x41, 0x44, 0x10, 0x13, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x4e, 0x59, 0x10, 0x14, 0x12, 0x07,
-	0x0a, 0x03, 0x48, 0x4b, 0x44, 0x10, 0x15, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x44, 0x52, 0x10, 0x16,
-	0x12, 0x07, 0x0a, 0x03, 0x49, 0x4c, 0x53, 0x10, 0x17, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4e, 0x52,
-	0x10, 0x18, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x52, 0x57, 0x10, 0x19, 0x12, 0x07, 0x0a, 0x03, 0x4d,
-	0x58, 0x4e, 0x10, 0x1a, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x59, 0x52, 0x10, 0x1b, 0x12, 0x07, 0x0a,
-	0x03, 0x4e, 0x5a, 0x44, 0x10, 0x1c, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x48, 0x50, 0x10, 0x1d, 0x12,
-	0x07, 0x0a, 0x03, 0x53, 0x47, 0x44, 0x10, 0x1e, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x48, 0x42, 0x10,
-	0x1f, 0x12, 0x07, 0x0a, 0x03, 0x5a, 0x41, 0x52, 0x10, 0x20, 0x32, 0x89, 0x01, 0x0a, 0x08, 0x43,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x38, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x52, 0x61,
-	0x74, 0x65, 0x12, 0x15, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x61,
-	0x74, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x13, 0x5a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+func (x *RatesRequest) GetDestinations() []string {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
 }
 
-var (
-	file_currency_proto_rawDescOnce sync.Once
-	file_currency_proto_rawDescData = file_currency_proto_rawDesc
-)
+type RatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_currency_proto_rawDescGZIP() []byte {
-	file_currency_proto_rawDescOnce.Do(func() {
-		file_currency_proto_rawDescData = protoimpl.X.CompressGZIP(file_currency_proto_rawDescData)
-	})
-	return file_currency_proto_rawDescData
+	// Rates in the order of the requested destinations
+	Rates []*RateResponse `protobuf:"bytes,2,rep,name=Rates,proto3" json:"Rates,omitempty"`
+	// Snapshot the rates were taken from, pass this to later GetRates and
+	// Convert calls to use the same rates
+	SnapshotID string `protobuf:"bytes,3,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
+	Base       string `protobuf:"bytes,4,opt,name=Base,proto3" json:"Base,omitempty"`
 }
 
-var file_currency_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_currency_proto_goTypes = []interface{}{
-	(Currencies)(0),      // 0: currency.Currencies
-	(*RateRequest)(nil),  // 1: currency.RateRequest
-	(*RateResponse)(nil), // 2: currency.RateResponse
+func (x *RatesResponse) Reset() {
+	*x = RatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_currency_proto_depIdxs = []int32{
-	0, // 0: currency.RateRequest.Base:type_name -> currency.Currencies
-	0, // 1: currency.RateRequest.Destination:type_name -> currency.Currencies
-	0, // 2: currency.RateResponse.Base:type_name -> currency.Currencies
-	0, // 3: currency.RateResponse.Destination:type_name -> currency.Currencies
-	1, // 4: currency.Currency.GetRate:input_type -> currency.RateRequest
-	1, // 5: currency.Currency.SubscribeRates:input_type -> currency.RateRequest
-	2, // 6: currency.Currency.GetRate:output_type -> currency.RateResponse
-	2, // 7: currency.Currency.SubscribeRates:output_type -> currency.RateResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *RatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_currency_proto_init() }
-func file_currency_proto_init() {
-	if File_currency_proto != nil {
-		return
+func (*RatesResponse) ProtoMessage() {}
+
+func (x *RatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_currency_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RateRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatesResponse.ProtoReflect.Descriptor instead.
+func (*RatesResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RatesResponse) GetRates() []*RateResponse {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *RatesResponse) GetSnapshotID() string {
+	if x != nil {
+		return x.SnapshotID
+	}
+	return ""
+}
+
+func (x *RatesResponse) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+type ConvertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Amount to convert as a decimal string, e.g. 2.45
+	Amount string `protobuf:"bytes,1,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	// Snapshot to take the rate from, when empty the latest rates are used
+	SnapshotID string `protobuf:"bytes,4,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
+	From       string `protobuf:"bytes,5,opt,name=From,proto3" json:"From,omitempty"`
+	To         string `protobuf:"bytes,6,opt,name=To,proto3" json:"To,omitempty"`
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_currency_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RateResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConvertRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetSnapshotID() string {
+	if x != nil {
+		return x.SnapshotID
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Converted amount as a decimal string, the amount is not rounded
+	Amount string  `protobuf:"bytes,1,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Rate   float64 `protobuf:"fixed64,4,opt,name=Rate,proto3" json:"Rate,omitempty"`
+	// Snapshot the rate was taken from
+	SnapshotID string `protobuf:"bytes,5,opt,name=SnapshotID,proto3" json:"SnapshotID,omitempty"`
+	From       string `protobuf:"bytes,6,opt,name=From,proto3" json:"From,omitempty"`
+	To         string `protobuf:"bytes,7,opt,name=To,proto3" json:"To,omitempty"`
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConvertResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ConvertResponse) GetSnapshotID() string {
+	if x != nil {
+		return x.SnapshotID
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// SubscribeRatesRequest is a message sent by the client on the rate stream
+type SubscribeRatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*SubscribeRatesRequest_Subscribe
+	//	*SubscribeRatesRequest_Unsubscribe
+	//	*SubscribeRatesRequest_List
+	Message isSubscribeRatesRequest_Message `protobuf_oneof:"Message"`
+}
+
+func (x *SubscribeRatesRequest) Reset() {
+	*x = SubscribeRatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRatesRequest) ProtoMessage() {}
+
+func (x *SubscribeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRatesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *SubscribeRatesRequest) GetMessage() isSubscribeRatesRequest_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *SubscribeRatesRequest) GetSubscribe() *RateRequest {
+	if x, ok := x.GetMessage().(*SubscribeRatesRequest_Subscribe); ok {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (x *SubscribeRatesRequest) GetUnsubscribe() *RateRequest {
+	if x, ok := x.GetMessage().(*SubscribeRatesRequest_Unsubscribe); ok {
+		return x.Unsubscribe
+	}
+	return nil
+}
+
+func (x *SubscribeRatesRequest) GetList() *ListSubscriptionsRequest {
+	if x, ok := x.GetMessage().(*SubscribeRatesRequest_List); ok {
+		return x.List
+	}
+	return nil
+}
+
+type isSubscribeRatesRequest_Message interface {
+	isSubscribeRatesRequest_Message()
+}
+
+type SubscribeRatesRequest_Subscribe struct {
+	// Subscribe to updates for a currency pair, subscribing to a pair
+	// more than once has no effect
+	Subscribe *RateRequest `protobuf:"bytes,1,opt,name=Subscribe,proto3,oneof"`
+}
+
+type SubscribeRatesRequest_Unsubscribe struct {
+	// Unsubscribe from updates for a currency pair
+	Unsubscribe *RateRequest `protobuf:"bytes,2,opt,name=Unsubscribe,proto3,oneof"`
+}
+
+type SubscribeRatesRequest_List struct {
+	// List the currency pairs the stream is subscribed to
+	List *ListSubscriptionsRequest `protobuf:"bytes,3,opt,name=List,proto3,oneof"`
+}
+
+func (*SubscribeRatesRequest_Subscribe) isSubscribeRatesRequest_Message() {}
+
+func (*SubscribeRatesRequest_Unsubscribe) isSubscribeRatesRequest_Message() {}
+
+func (*SubscribeRatesRequest_List) isSubscribeRatesRequest_Message() {}
+
+type ListSubscriptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSubscriptionsRequest) Reset() {
+	*x = ListSubscriptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{7}
+}
+
+// SubscribeRatesResponse is a message sent by the server on the rate stream
+type SubscribeRatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Message:
+	//	*SubscribeRatesResponse_RateResponse
+	//	*SubscribeRatesResponse_Ack
+	//	*SubscribeRatesResponse_Subscriptions
+	//	*SubscribeRatesResponse_Error
+	Message isSubscribeRatesResponse_Message `protobuf_oneof:"Message"`
+}
+
+func (x *SubscribeRatesResponse) Reset() {
+	*x = SubscribeRatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRatesResponse) ProtoMessage() {}
+
+func (x *SubscribeRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRatesResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeRatesResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *SubscribeRatesResponse) GetMessage() isSubscribeRatesResponse_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *SubscribeRatesResponse) GetRateResponse() *RateResponse {
+	if x, ok := x.GetMessage().(*SubscribeRatesResponse_RateResponse); ok {
+		return x.RateResponse
+	}
+	return nil
+}
+
+func (x *SubscribeRatesResponse) GetAck() *SubscriptionAck {
+	if x, ok := x.GetMessage().(*SubscribeRatesResponse_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+func (x *SubscribeRatesResponse) GetSubscriptions() *SubscriptionList {
+	if x, ok := x.GetMessage().(*SubscribeRatesResponse_Subscriptions); ok {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *SubscribeRatesResponse) GetError() *status.Status {
+	if x, ok := x.GetMessage().(*SubscribeRatesResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isSubscribeRatesResponse_Message interface {
+	isSubscribeRatesResponse_Message()
+}
+
+type SubscribeRatesResponse_RateResponse struct {
+	// An updated rate for a subscribed currency pair
+	RateResponse *RateResponse `protobuf:"bytes,1,opt,name=RateResponse,proto3,oneof"`
+}
+
+type SubscribeRatesResponse_Ack struct {
+	// Acknowledges a Subscribe or Unsubscribe message
+	Ack *SubscriptionAck `protobuf:"bytes,2,opt,name=Ack,proto3,oneof"`
+}
+
+type SubscribeRatesResponse_Subscriptions struct {
+	// The currency pairs the stream is subscribed to, sent in reply to a
+	// List message
+	Subscriptions *SubscriptionList `protobuf:"bytes,3,opt,name=Subscriptions,proto3,oneof"`
+}
+
+type SubscribeRatesResponse_Error struct {
+	// A message sent by the client could not be applied, the details
+	// contain the RateRequest which caused the error
+	Error *status.Status `protobuf:"bytes,4,opt,name=Error,proto3,oneof"`
+}
+
+func (*SubscribeRatesResponse_RateResponse) isSubscribeRatesResponse_Message() {}
+
+func (*SubscribeRatesResponse_Ack) isSubscribeRatesResponse_Message() {}
+
+func (*SubscribeRatesResponse_Subscriptions) isSubscribeRatesResponse_Message() {}
+
+func (*SubscribeRatesResponse_Error) isSubscribeRatesResponse_Message() {}
+
+type SubscriptionAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result  SubscriptionAck_Action `protobuf:"varint,1,opt,name=Result,proto3,enum=currency.v2.SubscriptionAck_Action" json:"Result,omitempty"`
+	Request *RateRequest           `protobuf:"bytes,2,opt,name=Request,proto3" json:"Request,omitempty"`
+}
+
+func (x *SubscriptionAck) Reset() {
+	*x = SubscriptionAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionAck) ProtoMessage() {}
+
+func (x *SubscriptionAck) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionAck.ProtoReflect.Descriptor instead.
+func (*SubscriptionAck) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscriptionAck) GetResult() SubscriptionAck_Action {
+	if x != nil {
+		return x.Result
+	}
+	return SubscriptionAck_SUBSCRIBED
+}
+
+func (x *SubscriptionAck) GetRequest() *RateRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type SubscriptionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscriptions []*RateRequest `protobuf:"bytes,1,rep,name=Subscriptions,proto3" json:"Subscriptions,omitempty"`
+}
+
+func (x *SubscriptionList) Reset() {
+	*x = SubscriptionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionList) ProtoMessage() {}
+
+func (x *SubscriptionList) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionList.ProtoReflect.Descriptor instead.
+func (*SubscriptionList) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscriptionList) GetSubscriptions() []*RateRequest {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type HistoricalRateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Date the rate applies to in the format YYYY-MM-DD
+	Date        string `protobuf:"bytes,3,opt,name=Date,proto3" json:"Date,omitempty"`
+	Base        string `protobuf:"bytes,4,opt,name=Base,proto3" json:"Base,omitempty"`
+	Destination string `protobuf:"bytes,5,opt,name=Destination,proto3" json:"Destination,omitempty"`
+}
+
+func (x *HistoricalRateRequest) Reset() {
+	*x = HistoricalRateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoricalRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricalRateRequest) ProtoMessage() {}
+
+func (x *HistoricalRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricalRateRequest.ProtoReflect.Descriptor instead.
+func (*HistoricalRateRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HistoricalRateRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *HistoricalRateRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *HistoricalRateRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type HistoricalRateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate float64 `protobuf:"fixed64,3,opt,name=Rate,proto3" json:"Rate,omitempty"`
+	// Date the rate was published in the format YYYY-MM-DD, no rates are
+	// published on weekends and holidays so this may be before the requested date
+	Date        string `protobuf:"bytes,4,opt,name=Date,proto3" json:"Date,omitempty"`
+	Base        string `protobuf:"bytes,5,opt,name=Base,proto3" json:"Base,omitempty"`
+	Destination string `protobuf:"bytes,6,opt,name=Destination,proto3" json:"Destination,omitempty"`
+}
+
+func (x *HistoricalRateResponse) Reset() {
+	*x = HistoricalRateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoricalRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricalRateResponse) ProtoMessage() {}
+
+func (x *HistoricalRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricalRateResponse.ProtoReflect.Descriptor instead.
+func (*HistoricalRateResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HistoricalRateResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *HistoricalRateResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *HistoricalRateResponse) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *HistoricalRateResponse) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type ListCurrenciesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesRequest) ProtoMessage() {}
+
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{13}
+}
+
+type ListCurrenciesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Currencies with a rate, ordered by code
+	Currencies []*CurrencyInfo `protobuf:"bytes,1,rep,name=Currencies,proto3" json:"Currencies,omitempty"`
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesResponse) ProtoMessage() {}
+
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListCurrenciesResponse) GetCurrencies() []*CurrencyInfo {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+type CurrencyInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ISO 4217 code, e.g. EUR
+	Code   string `protobuf:"bytes,1,opt,name=Code,proto3" json:"Code,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Symbol string `protobuf:"bytes,3,opt,name=Symbol,proto3" json:"Symbol,omitempty"`
+	// Time the rate was last updated in RFC 3339 format
+	Updated string `protobuf:"bytes,4,opt,name=Updated,proto3" json:"Updated,omitempty"`
+	// Provider the rate came from, e.g. ecb
+	Source string `protobuf:"bytes,5,opt,name=Source,proto3" json:"Source,omitempty"`
+}
+
+func (x *CurrencyInfo) Reset() {
+	*x = CurrencyInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_currency_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CurrencyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyInfo) ProtoMessage() {}
+
+func (x *CurrencyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_currency_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyInfo.ProtoReflect.Descriptor instead.
+func (*CurrencyInfo) Descriptor() ([]byte, []int) {
+	return file_currency_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CurrencyInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CurrencyInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CurrencyInfo) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CurrencyInfo) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+func (x *CurrencyInfo) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+var File_currency_proto protoreflect.FileDescriptor
+
+var file_currency_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x1a, 0x17, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x42, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x42, 0x61, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x01, 0x10,
+	0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x52, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x61, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04,
+	0x42, 0x61, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x42, 0x61, 0x73, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x72,
+	0x0a, 0x0c, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x12, 0x12,
+	0x0a, 0x04, 0x42, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x42, 0x61,
+	0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02,
+	0x10, 0x03, 0x22, 0x7a, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x52, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32,
+	0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x05, 0x52,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x42, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x42, 0x61, 0x73, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x78,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x54, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x4a, 0x04, 0x08, 0x02,
+	0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x8d, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x54, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x4a, 0x04, 0x08, 0x02,
+	0x10, 0x03, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0xd7, 0x01, 0x0a, 0x15, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x2e, 0x76, 0x32, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x3c, 0x0a, 0x0b,
+	0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e,
+	0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x55,
+	0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x89,
+	0x02, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x52, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x52, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x03, 0x41, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x45, 0x0a, 0x0d,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76,
+	0x32, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x42,
+	0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x0f, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x12, 0x3b,
+	0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23,
+	0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x6b, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x07, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x56, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x55, 0x42,
+	0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x4c, 0x52,
+	0x45, 0x41, 0x44, 0x59, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x43,
+	0x52, 0x49, 0x42, 0x45, 0x44, 0x10, 0x03, 0x22, 0x52, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32,
+	0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6d, 0x0a, 0x15, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x44, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x42, 0x61, 0x73, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x42, 0x61, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x82, 0x01, 0x0a, 0x16, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x44, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x42, 0x61, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x42, 0x61, 0x73,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22,
+	0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x53, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0a, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x22, 0x80, 0x01,
+	0x0a, 0x0c, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12,
+	0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x32, 0xeb, 0x03, 0x0a, 0x08, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x3e, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32,
+	0x2e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a,
+	0x0e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x22, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76,
+	0x32, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x22, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x2e, 0x76, 0x32, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x52, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e,
+	0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
+	0x07, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x2e, 0x76, 0x32, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x13,
+	0x5a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_currency_proto_rawDescOnce sync.Once
+	file_currency_proto_rawDescData = file_currency_proto_rawDesc
+)
+
+func file_currency_proto_rawDescGZIP() []byte {
+	file_currency_proto_rawDescOnce.Do(func() {
+		file_currency_proto_rawDescData = protoimpl.X.CompressGZIP(file_currency_proto_rawDescData)
+	})
+	return file_currency_proto_rawDescData
+}
+
+var file_currency_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_currency_proto_goTypes = []interface{}{
+	(SubscriptionAck_Action)(0),      // 0: currency.v2.SubscriptionAck.Action
+	(*RateRequest)(nil),              // 1: currency.v2.RateRequest
+	(*RateResponse)(nil),             // 2: currency.v2.RateResponse
+	(*RatesRequest)(nil),             // 3: currency.v2.RatesRequest
+	(*RatesResponse)(nil),            // 4: currency.v2.RatesResponse
+	(*ConvertRequest)(nil),           // 5: currency.v2.ConvertRequest
+	(*ConvertResponse)(nil),          // 6: currency.v2.ConvertResponse
+	(*SubscribeRatesRequest)(nil),    // 7: currency.v2.SubscribeRatesRequest
+	(*ListSubscriptionsRequest)(nil), // 8: currency.v2.ListSubscriptionsRequest
+	(*SubscribeRatesResponse)(nil),   // 9: currency.v2.SubscribeRatesResponse
+	(*SubscriptionAck)(nil),          // 10: currency.v2.SubscriptionAck
+	(*SubscriptionList)(nil),         // 11: currency.v2.SubscriptionList
+	(*HistoricalRateRequest)(nil),    // 12: currency.v2.HistoricalRateRequest
+	(*HistoricalRateResponse)(nil),   // 13: currency.v2.HistoricalRateResponse
+	(*ListCurrenciesRequest)(nil),    // 14: currency.v2.ListCurrenciesRequest
+	(*ListCurrenciesResponse)(nil),   // 15: currency.v2.ListCurrenciesResponse
+	(*CurrencyInfo)(nil),             // 16: currency.v2.CurrencyInfo
+	(*status.Status)(nil),            // 17: google.rpc.Status
+}
+var file_currency_proto_depIdxs = []int32{
+	2,  // 0: currency.v2.RatesResponse.Rates:type_name -> currency.v2.RateResponse
+	1,  // 1: currency.v2.SubscribeRatesRequest.Subscribe:type_name -> currency.v2.RateRequest
+	1,  // 2: currency.v2.SubscribeRatesRequest.Unsubscribe:type_name -> currency.v2.RateRequest
+	8,  // 3: currency.v2.SubscribeRatesRequest.List:type_name -> currency.v2.ListSubscriptionsRequest
+	2,  // 4: currency.v2.SubscribeRatesResponse.RateResponse:type_name -> currency.v2.RateResponse
+	10, // 5: currency.v2.SubscribeRatesResponse.Ack:type_name -> currency.v2.SubscriptionAck
+	11, // 6: currency.v2.SubscribeRatesResponse.Subscriptions:type_name -> currency.v2.SubscriptionList
+	17, // 7: currency.v2.SubscribeRatesResponse.Error:type_name -> google.rpc.Status
+	0,  // 8: currency.v2.SubscriptionAck.Result:type_name -> currency.v2.SubscriptionAck.Action
+	1,  // 9: currency.v2.SubscriptionAck.Request:type_name -> currency.v2.RateRequest
+	1,  // 10: currency.v2.SubscriptionList.Subscriptions:type_name -> currency.v2.RateRequest
+	16, // 11: currency.v2.ListCurrenciesResponse.Currencies:type_name -> currency.v2.CurrencyInfo
+	1,  // 12: currency.v2.Currency.GetRate:input_type -> currency.v2.RateRequest
+	7,  // 13: currency.v2.Currency.SubscribeRates:input_type -> currency.v2.SubscribeRatesRequest
+	12, // 14: currency.v2.Currency.GetHistoricalRate:input_type -> currency.v2.HistoricalRateRequest
+	3,  // 15: currency.v2.Currency.GetRates:input_type -> currency.v2.RatesRequest
+	5,  // 16: currency.v2.Currency.Convert:input_type -> currency.v2.ConvertRequest
+	14, // 17: currency.v2.Currency.ListCurrencies:input_type -> currency.v2.ListCurrenciesRequest
+	2,  // 18: currency.v2.Currency.GetRate:output_type -> currency.v2.RateResponse
+	9,  // 19: currency.v2.Currency.SubscribeRates:output_type -> currency.v2.SubscribeRatesResponse
+	13, // 20: currency.v2.Currency.GetHistoricalRate:output_type -> currency.v2.HistoricalRateResponse
+	4,  // 21: currency.v2.Currency.GetRates:output_type -> currency.v2.RatesResponse
+	6,  // 22: currency.v2.Currency.Convert:output_type -> currency.v2.ConvertResponse
+	15, // 23: currency.v2.Currency.ListCurrencies:output_type -> currency.v2.ListCurrenciesResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_currency_proto_init() }
+func file_currency_proto_init() {
+	if File_currency_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_currency_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvertRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvertResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSubscriptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoricalRateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoricalRateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCurrenciesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCurrenciesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_currency_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CurrencyInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_currency_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*SubscribeRatesRequest_Subscribe)(nil),
+		(*SubscribeRatesRequest_Unsubscribe)(nil),
+		(*SubscribeRatesRequest_List)(nil),
+	}
+	file_currency_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*SubscribeRatesResponse_RateResponse)(nil),
+		(*SubscribeRatesResponse_Ack)(nil),
+		(*SubscribeRatesResponse_Subscriptions)(nil),
+		(*SubscribeRatesResponse_Error)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -407,7 +1608,7 @@ func file_currency_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_currency_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,6 +19,10 @@ const _ = grpc.SupportPackageIsVersion6
 type CurrencyClient interface {
 	GetRate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error)
 	SubscribeRates(ctx context.Context, opts ...grpc.CallOption) (Currency_SubscribeRatesClient, error)
+	GetHistoricalRate(ctx context.Context, in *HistoricalRateRequest, opts ...grpc.CallOption) (*HistoricalRateResponse, error)
+	GetRates(ctx context.Context, in *RatesRequest, opts ...grpc.CallOption) (*RatesResponse, error)
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
 }
 
 type currencyClient struct {
@@ -31,7 +35,7 @@ func NewCurrencyClient(cc grpc.ClientConnInterface) CurrencyClient {
 
 func (c *currencyClient) GetRate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error) {
 	out := new(RateResponse)
-	err := c.cc.Invoke(ctx, "/currency.Currency/GetRate", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/currency.v2.Currency/GetRate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +43,7 @@ func (c *currencyClient) GetRate(ctx context.Context, in *RateRequest, opts ...g
 }
 
 func (c *currencyClient) SubscribeRates(ctx context.Context, opts ...grpc.CallOption) (Currency_SubscribeRatesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Currency_serviceDesc.Streams[0], "/currency.Currency/SubscribeRates", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Currency_serviceDesc.Streams[0], "/currency.v2.Currency/SubscribeRates", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +52,8 @@ func (c *currencyClient) SubscribeRates(ctx context.Context, opts ...grpc.CallOp
 }
 
 type Currency_SubscribeRatesClient interface {
-	Send(*RateRequest) error
-	Recv() (*RateResponse, error)
+	Send(*SubscribeRatesRequest) error
+	Recv() (*SubscribeRatesResponse, error)
 	grpc.ClientStream
 }
 
@@ -57,24 +61,64 @@ type currencySubscribeRatesClient struct {
 	grpc.ClientStream
 }
 
-func (x *currencySubscribeRatesClient) Send(m *RateRequest) error {
+func (x *currencySubscribeRatesClient) Send(m *SubscribeRatesRequest) error {
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *currencySubscribeRatesClient) Recv() (*RateResponse, error) {
-	m := new(RateResponse)
+func (x *currencySubscribeRatesClient) Recv() (*SubscribeRatesResponse, error) {
+	m := new(SubscribeRatesResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+func (c *currencyClient) GetHistoricalRate(ctx context.Context, in *HistoricalRateRequest, opts ...grpc.CallOption) (*HistoricalRateResponse, error) {
+	out := new(HistoricalRateResponse)
+	err := c.cc.Invoke(ctx, "/currency.v2.Currency/GetHistoricalRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyClient) GetRates(ctx context.Context, in *RatesRequest, opts ...grpc.CallOption) (*RatesResponse, error) {
+	out := new(RatesResponse)
+	err := c.cc.Invoke(ctx, "/currency.v2.Currency/GetRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, "/currency.v2.Currency/Convert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, "/currency.v2.Currency/ListCurrencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CurrencyServer is the server API for Currency service.
 // All implementations must embed UnimplementedCurrencyServer
 // for forward compatibility
 type CurrencyServer interface {
 	GetRate(context.Context, *RateRequest) (*RateResponse, error)
 	SubscribeRates(Currency_SubscribeRatesServer) error
+	GetHistoricalRate(context.Context, *HistoricalRateRequest) (*HistoricalRateResponse, error)
+	GetRates(context.Context, *RatesRequest) (*RatesResponse, error)
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
 	mustEmbedUnimplementedCurrencyServer()
 }
 
@@ -88,6 +132,18 @@ func (*UnimplementedCurrencyServer) GetRate(context.Context, *RateRequest) (*Rat
 func (*UnimplementedCurrencyServer) SubscribeRates(Currency_SubscribeRatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeRates not implemented")
 }
+func (*UnimplementedCurrencyServer) GetHistoricalRate(context.Context, *HistoricalRateRequest) (*HistoricalRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistoricalRate not implemented")
+}
+func (*UnimplementedCurrencyServer) GetRates(context.Context, *RatesRequest) (*RatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRates not implemented")
+}
+func (*UnimplementedCurrencyServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (*UnimplementedCurrencyServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
 func (*UnimplementedCurrencyServer) mustEmbedUnimplementedCurrencyServer() {}
 
 func RegisterCurrencyServer(s *grpc.Server, srv CurrencyServer) {
@@ -104,7 +160,7 @@ func _Currency_GetRate_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/currency.Currency/GetRate",
+		FullMethod: "/currency.v2.Currency/GetRate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(CurrencyServer).GetRate(ctx, req.(*RateRequest))
@@ -117,8 +173,8 @@ func _Currency_SubscribeRates_Handler(srv interface{}, stream grpc.ServerStream)
 }
 
 type Currency_SubscribeRatesServer interface {
-	Send(*RateResponse) error
-	Recv() (*RateRequest, error)
+	Send(*SubscribeRatesResponse) error
+	Recv() (*SubscribeRatesRequest, error)
 	grpc.ServerStream
 }
 
@@ -126,26 +182,114 @@ type currencySubscribeRatesServer struct {
 	grpc.ServerStream
 }
 
-func (x *currencySubscribeRatesServer) Send(m *RateResponse) error {
+func (x *currencySubscribeRatesServer) Send(m *SubscribeRatesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *currencySubscribeRatesServer) Recv() (*RateRequest, error) {
-	m := new(RateRequest)
+func (x *currencySubscribeRatesServer) Recv() (*SubscribeRatesRequest, error) {
+	m := new(SubscribeRatesRequest)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
+func _Currency_GetHistoricalRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoricalRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).GetHistoricalRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currency.v2.Currency/GetHistoricalRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).GetHistoricalRate(ctx, req.(*HistoricalRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Currency_GetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).GetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currency.v2.Currency/GetRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).GetRates(ctx, req.(*RatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Currency_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currency.v2.Currency/Convert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Currency_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currency.v2.Currency/ListCurrencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Currency_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "currency.Currency",
+	ServiceName: "currency.v2.Currency",
 	HandlerType: (*CurrencyServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "GetRate",
 			Handler:    _Currency_GetRate_Handler,
 		},
+		{
+			MethodName: "GetHistoricalRate",
+			Handler:    _Currency_GetHistoricalRate_Handler,
+		},
+		{
+			MethodName: "GetRates",
+			Handler:    _Currency_GetRates_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _Currency_Convert_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _Currency_ListCurrencies_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
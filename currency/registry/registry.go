@@ -0,0 +1,77 @@
+// Package registry contains the ISO 4217 currencies supported by the
+// currency service
+package registry
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownCurrency is an error raised when a code is not an ISO 4217
+// currency in circulation
+var ErrUnknownCurrency = fmt.Errorf("Unknown currency")
+
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	// Code is the three letter ISO 4217 code, e.g. EUR
+	Code string
+
+	// Name is the English name of the currency
+	Name string
+
+	// Symbol is the symbol commonly used for the currency, the code is used
+	// when there is no common symbol
+	Symbol string
+
+	// MinorUnits is the number of decimal places used by the currency
+	MinorUnits int
+}
+
+// byCode indexes currencies by their code
+var byCode = map[string]Currency{}
+
+func init() {
+	for _, c := range currencies {
+		byCode[c.Code] = c
+	}
+}
+
+// Lookup returns the currency with the given code, codes are case sensitive
+// and must be upper case
+func Lookup(code string) (Currency, error) {
+	c, ok := byCode[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w %q, expected an ISO 4217 code such as EUR", ErrUnknownCurrency, code)
+	}
+
+	return c, nil
+}
+
+// Valid returns true when the code is a known currency
+func Valid(code string) bool {
+	_, ok := byCode[code]
+	return ok
+}
+
+// MinorUnits returns the number of decimal places used by the currency, 2 is
+// returned for unknown codes
+func MinorUnits(code string) int {
+	c, ok := byCode[code]
+	if !ok {
+		return 2
+	}
+
+	return c.MinorUnits
+}
+
+// All returns every known currency ordered by code
+func All() []Currency {
+	cs := make([]Currency, len(currencies))
+	copy(cs, currencies)
+
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].Code < cs[j].Code
+	})
+
+	return cs
+}
@@ -0,0 +1,48 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	c, err := Lookup("JPY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "Yen" || c.Symbol != "¥" || c.MinorUnits != 0 {
+		t.Fatalf("unexpected currency %#v", c)
+	}
+}
+
+func TestLookupUnknownCurrency(t *testing.T) {
+	for _, code := range []string{"", "XXX", "eur", "HRK"} {
+		_, err := Lookup(code)
+		if !errors.Is(err, ErrUnknownCurrency) {
+			t.Fatalf("expected ErrUnknownCurrency for %q got %v", code, err)
+		}
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	cases := map[string]int{"EUR": 2, "JPY": 0, "ISK": 0, "KWD": 3, "XXX": 2}
+	for code, want := range cases {
+		if got := MinorUnits(code); got != want {
+			t.Fatalf("expected %d minor units for %s got %d", want, code, got)
+		}
+	}
+}
+
+func TestCurrenciesAreUniqueAndOrdered(t *testing.T) {
+	all := All()
+	if len(all) != len(byCode) {
+		t.Fatalf("expected %d unique currencies got %d", len(all), len(byCode))
+	}
+
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Code >= all[i].Code {
+			t.Fatalf("currencies not ordered at %s", all[i].Code)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// files written by GenerateDevCerts
+const (
+	CAFile        = "ca.pem"
+	CAKeyFile     = "ca-key.pem"
+	ServerFile    = "server.pem"
+	ServerKeyFile = "server-key.pem"
+	ClientFile    = "client.pem"
+	ClientKeyFile = "client-key.pem"
+)
+
+// GenerateDevCerts writes a development CA to dir along with a server
+// certificate for the hosts and a client certificate, both signed by the CA.
+// The certificates are for running the services securely on one machine and
+// must not be used in production
+func GenerateDevCerts(dir string, hosts []string, validFor time.Duration) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("Unable to create certificate directory: %w", err)
+	}
+
+	now := time.Now()
+	ca := &x509.Certificate{
+		Subject:               pkix.Name{Organization: []string{"golang-mux-rest-api development"}, CommonName: "Development CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validFor),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	caCert, caKey, err := writeCert(dir, CAFile, CAKeyFile, ca, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "currency"},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(validFor),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, h)
+		}
+	}
+	_, _, err = writeCert(dir, ServerFile, ServerKeyFile, server, caCert, caKey)
+	if err != nil {
+		return err
+	}
+
+	client := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "products-rest-api"},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(validFor),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	_, _, err = writeCert(dir, ClientFile, ClientKeyFile, client, caCert, caKey)
+
+	return err
+}
+
+// writeCert creates a key and a certificate from the template signed by the
+// parent, or self signed when the parent is nil, and writes them as PEM files
+func writeCert(dir, certFile, keyFile string, template, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to generate key: %w", err)
+	}
+
+	template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to generate serial number: %w", err)
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to create certificate %s: %w", certFile, err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Unable to encode key %s: %w", keyFile, err)
+	}
+
+	err = writePEM(filepath.Join(dir, certFile), "CERTIFICATE", der, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// private keys are only readable by their owner
+	err = writePEM(filepath.Join(dir, keyFile), "PRIVATE KEY", keyDER, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
+}
+
+// writePEM writes the DER bytes to the file as a PEM block
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	b := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+
+	err := ioutil.WriteFile(path, b, perm)
+	if err != nil {
+		return fmt.Errorf("Unable to write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package security
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// devCerts generates the development certificates in a temporary directory
+func devCerts(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "certs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	err = GenerateDevCerts(dir, []string{"localhost", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+// serveTLS starts a gRPC server with the health service on a random port
+func serveTLS(t *testing.T, dir string, clientCAFile string) string {
+	tc, err := ServerTLSConfig(filepath.Join(dir, ServerFile), filepath.Join(dir, ServerKeyFile), clientCAFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gs := grpc.NewServer(grpc.Creds(credentials.NewTLS(tc)))
+	healthpb.RegisterHealthServer(gs, health.NewServer())
+	go gs.Serve(l)
+	t.Cleanup(gs.Stop)
+
+	return l.Addr().String()
+}
+
+// check calls the health service using the client TLS config
+func check(t *testing.T, addr string, certFile, keyFile string, dir string) error {
+	tc, err := ClientTLSConfig(filepath.Join(dir, CAFile), certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(credentials.NewTLS(tc)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestTLSVerifiesServerWithDevCA(t *testing.T) {
+	dir := devCerts(t)
+	addr := serveTLS(t, dir, "")
+
+	if err := check(t, addr, "", "", dir); err != nil {
+		t.Fatalf("expected call over TLS to succeed got %s", err)
+	}
+}
+
+func TestMutualTLSRequiresClientCertificate(t *testing.T) {
+	dir := devCerts(t)
+	addr := serveTLS(t, dir, filepath.Join(dir, CAFile))
+
+	if err := check(t, addr, "", "", dir); err == nil {
+		t.Fatal("expected call without a client certificate to fail")
+	}
+
+	err := check(t, addr, filepath.Join(dir, ClientFile), filepath.Join(dir, ClientKeyFile), dir)
+	if err != nil {
+		t.Fatalf("expected call with a client certificate to succeed got %s", err)
+	}
+}
+
+func TestMutualTLSRejectsUnknownCA(t *testing.T) {
+	dir := devCerts(t)
+	other := devCerts(t)
+	addr := serveTLS(t, dir, filepath.Join(dir, CAFile))
+
+	err := check(t, addr, filepath.Join(other, ClientFile), filepath.Join(other, ClientKeyFile), dir)
+	if err == nil {
+		t.Fatal("expected call with a certificate from another CA to fail")
+	}
+}
+
+func TestDevKeysAreOwnerOnly(t *testing.T) {
+	dir := devCerts(t)
+
+	for _, f := range []string{CAKeyFile, ServerKeyFile, ClientKeyFile} {
+		fi, err := os.Stat(filepath.Join(dir, f))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if fi.Mode().Perm() != 0600 {
+			t.Fatalf("expected %s to be 0600 got %s", f, fi.Mode().Perm())
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package security
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataAuthorization is the metadata key bearer tokens are sent with
+const MetadataAuthorization = "authorization"
+
+// bearerPrefix is the scheme of the authorization metadata
+const bearerPrefix = "Bearer "
+
+// tokenCredentials sends a bearer token with every call
+type tokenCredentials struct {
+	token string
+}
+
+// BearerToken returns credentials which send the token with every call, the
+// token is only sent over TLS so it can not be read from the network
+func BearerToken(token string) credentials.PerRPCCredentials {
+	return tokenCredentials{token}
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{MetadataAuthorization: bearerPrefix + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// healthMethodPrefix is the prefix of the health check methods, which are
+// called by load balancers and orchestrators without a token
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// ErrInvalidToken is returned when a call has a missing or unknown token
+var ErrInvalidToken = status.Error(codes.Unauthenticated, "Missing or invalid bearer token")
+
+// TokenAuth checks the bearer token sent with every call is one of the
+// configured tokens, more than one token can be configured so they can be
+// rotated without downtime
+type TokenAuth struct {
+	tokens [][]byte
+	log    hclog.Logger
+}
+
+// NewTokenAuth returns a TokenAuth accepting the tokens
+func NewTokenAuth(tokens []string, l hclog.Logger) *TokenAuth {
+	ta := &TokenAuth{log: l}
+	for _, t := range tokens {
+		ta.tokens = append(ta.tokens, []byte(t))
+	}
+
+	return ta
+}
+
+// UnaryServerInterceptor rejects unary calls without a valid token
+func (ta *TokenAuth) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := ta.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects streams without a valid token
+func (ta *TokenAuth) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := ta.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// authorize returns ErrInvalidToken unless the call is a health check or has
+// a valid token
+func (ta *TokenAuth) authorize(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, healthMethodPrefix) {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(MetadataAuthorization) {
+		if len(v) > len(bearerPrefix) && strings.EqualFold(v[:len(bearerPrefix)], bearerPrefix) && ta.valid([]byte(v[len(bearerPrefix):])) {
+			return nil
+		}
+	}
+
+	ta.log.Warn("Rejected call with a missing or invalid token", "method", method)
+	return ErrInvalidToken
+}
+
+// valid returns true when the token is one of the configured tokens, every
+// token is compared in constant time so the time taken does not leak which
+// token nearly matched
+func (ta *TokenAuth) valid(token []byte) bool {
+	ok := 0
+	for _, t := range ta.tokens {
+		ok |= subtle.ConstantTimeCompare(token, t)
+	}
+
+	return ok == 1
+}
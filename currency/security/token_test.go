@@ -0,0 +1,93 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAuth(t *testing.T) {
+	ta := NewTokenAuth([]string{"current-token", "previous-token"}, hclog.NewNullLogger())
+
+	tests := []struct {
+		name   string
+		method string
+		md     metadata.MD
+		code   codes.Code
+	}{
+		{"current token", "/currency.v2.Currency/GetRate", metadata.Pairs(MetadataAuthorization, "Bearer current-token"), codes.OK},
+		{"rotated token", "/currency.v2.Currency/GetRate", metadata.Pairs(MetadataAuthorization, "Bearer previous-token"), codes.OK},
+		{"scheme is case insensitive", "/currency.v2.Currency/GetRate", metadata.Pairs(MetadataAuthorization, "bearer current-token"), codes.OK},
+		{"missing token", "/currency.v2.Currency/GetRate", metadata.MD{}, codes.Unauthenticated},
+		{"unknown token", "/currency.v2.Currency/GetRate", metadata.Pairs(MetadataAuthorization, "Bearer current"), codes.Unauthenticated},
+		{"wrong scheme", "/currency.v2.Currency/GetRate", metadata.Pairs(MetadataAuthorization, "Basic current-token"), codes.Unauthenticated},
+		{"health check", "/grpc.health.v1.Health/Check", metadata.MD{}, codes.OK},
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+
+			_, err := ta.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if status.Code(err) != tc.code {
+				t.Fatalf("expected %s got %s", tc.code, status.Code(err))
+			}
+		})
+	}
+}
+
+// fakeStream is a server stream with the incoming context of a call
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestTokenAuthStream(t *testing.T) {
+	ta := NewTokenAuth([]string{"current-token"}, hclog.NewNullLogger())
+	info := &grpc.StreamServerInfo{FullMethod: "/currency.v2.Currency/SubscribeRates"}
+	called := false
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	err := ta.StreamServerInterceptor(nil, fakeStream{ctx: context.Background()}, info, handler)
+	if status.Code(err) != codes.Unauthenticated || called {
+		t.Fatalf("expected stream without a token to be rejected got %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAuthorization, "Bearer current-token"))
+	err = ta.StreamServerInterceptor(nil, fakeStream{ctx: ctx}, info, handler)
+	if err != nil || !called {
+		t.Fatalf("expected stream with a token to be handled got %v", err)
+	}
+}
+
+func TestBearerTokenRequiresTLS(t *testing.T) {
+	c := BearerToken("current-token")
+	if !c.RequireTransportSecurity() {
+		t.Fatal("expected token to require TLS")
+	}
+
+	md, err := c.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if md[MetadataAuthorization] != "Bearer current-token" {
+		t.Fatalf("unexpected authorization %q", md[MetadataAuthorization])
+	}
+}
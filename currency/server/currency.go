@@ -8,94 +8,362 @@ import (
 	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
 	"github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
 	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/registry"
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// MetadataRequestID is the metadata key clients send the ID of the request
+// which caused the call with, it is logged with the call
+const MetadataRequestID = "x-request-id"
+
 type Currency struct {
-	rates         *data.ExchangeRates
-	log           hclog.Logger
-	subscriptions map[protos.Currency_SubscribeRatesServer][]*protos.RateRequest
+	rates *data.ExchangeRates
+	log   hclog.Logger
+	hub   *Hub
 	currencypb.UnimplementedCurrencyServer
 }
 
 func NewCurrency(r *data.ExchangeRates, l hclog.Logger) *Currency {
 
-	c := &Currency{r, l, make(map[protos.Currency_SubscribeRatesServer][]*protos.RateRequest), currencypb.UnimplementedCurrencyServer{}}
+	c := &Currency{rates: r, log: l, hub: NewHub(l, DefaultQueueSize)}
 	go c.handleUpates()
 	return c
 }
 
+// logger returns the logger for the call, which logs the request ID sent by
+// the client
+func (c *Currency) logger(ctx context.Context) hclog.Logger {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(MetadataRequestID); len(ids) > 0 {
+		return c.log.With("request_id", ids[0])
+	}
+
+	return c.log
+}
+
+// handleUpates sends the changed rates to the subscribers every time the
+// exchange rates are updated
 func (c *Currency) handleUpates() {
-	ru := c.rates.MonitorRates(5 * time.Second)
-	for range ru {
-		c.log.Info("Got updated rates")
-
-		// loop over subscribed clients
-		for k, v := range c.subscriptions {
-
-			// loop over subsribed rates
-			for _, rr := range v {
-				rate, err := c.rates.GetRate(rr.GetBase().String(), rr.GetDestination().String())
-				if err != nil {
-					c.log.Error("Unable to get updated rate", "base", rr.GetBase().String(), "destination", rr.GetDestination().String())
-
-					err = k.Send(&protos.RateResponse{Base: rr.Base, Destination: rr.Destination, Rate: rate})
-					if err != nil {
-						c.log.Error("Unable to send updated rate", "base", rr.GetBase().String(), "destination", rr.GetDestination().String())
-					}
-				}
-			}
-		}
+	for range c.rates.Updates() {
+		c.log.Info("Got updated rates", "subscribers", c.hub.Len())
+
+		start := time.Now()
+		c.hub.Publish(c.rates.Snapshot())
+		fanOutSeconds.Observe(time.Since(start).Seconds())
 	}
 }
 
 func (c *Currency) GetRate(ctx context.Context, rr *protos.RateRequest) (*protos.RateResponse, error) {
-	c.log.Info("Handle get rate", "base", rr.GetBase(), "destination", rr.GetDestination())
-
-	if rr.Base == rr.Destination {
-		err := status.Newf(
-			codes.InvalidArgument,
-			"Base currency %s can not be the same as the destination currency %s",
-			rr.Base.String(),
-			rr.Destination.String(),
-		)
-
-		err, wde := err.WithDetails(rr)
-		if wde != nil {
-			return nil, wde
+	c.logger(ctx).Info("Handle get rate", "base", rr.GetBase(), "destination", rr.GetDestination())
+
+	if s := validateRateRequest(rr); s != nil {
+		return nil, s.Err()
+	}
+
+	snap := c.rates.Snapshot()
+
+	rate, err := snap.Rate(rr.GetBase(), rr.GetDestination())
+	if err != nil {
+		return nil, withDetails(status.New(codes.NotFound, err.Error()), rr).Err()
+	}
+	return &protos.RateResponse{Base: rr.GetBase(), Destination: rr.GetDestination(), Rate: rate, SnapshotID: snap.ID}, nil
+}
+
+// GetRates returns the rates from the base to each of the destinations, all
+// taken from the same snapshot
+func (c *Currency) GetRates(ctx context.Context, rr *protos.RatesRequest) (*protos.RatesResponse, error) {
+	c.logger(ctx).Info("Handle get rates", "base", rr.GetBase(), "destinations", rr.GetDestinations(), "snapshot", rr.GetSnapshotID())
+
+	if len(rr.GetDestinations()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "At least one destination currency is required")
+	}
+
+	snap, err := c.snapshot(rr.GetSnapshotID())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &protos.RatesResponse{Base: rr.GetBase(), SnapshotID: snap.ID}
+	for _, d := range rr.GetDestinations() {
+		req := &protos.RateRequest{Base: rr.GetBase(), Destination: d}
+		if s := validateRateRequest(req); s != nil {
+			return nil, s.Err()
+		}
+
+		rate, err := snap.Rate(rr.GetBase(), d)
+		if err != nil {
+			return nil, withDetails(status.New(codes.NotFound, err.Error()), req).Err()
 		}
 
-		return nil, err.Err()
+		res.Rates = append(res.Rates, &protos.RateResponse{Base: rr.GetBase(), Destination: d, Rate: rate, SnapshotID: snap.ID})
 	}
 
-	rate, err := c.rates.GetRate(rr.GetBase().String(), rr.GetDestination().String())
+	return res, nil
+}
+
+// Convert converts an amount between two currencies, the amount is a decimal
+// string so no precision is lost
+func (c *Currency) Convert(ctx context.Context, cr *protos.ConvertRequest) (*protos.ConvertResponse, error) {
+	c.logger(ctx).Info("Handle convert", "amount", cr.GetAmount(), "from", cr.GetFrom(), "to", cr.GetTo(), "snapshot", cr.GetSnapshotID())
+
+	amount, err := decimal.NewFromString(cr.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid amount %q, expected a decimal number", cr.GetAmount())
+	}
+
+	for _, code := range []string{cr.GetFrom(), cr.GetTo()} {
+		if _, err := registry.Lookup(code); err != nil {
+			return nil, withDetails(status.New(codes.InvalidArgument, err.Error()), cr).Err()
+		}
+	}
+
+	snap, err := c.snapshot(cr.GetSnapshotID())
 	if err != nil {
 		return nil, err
 	}
-	return &protos.RateResponse{Base: rr.GetBase(), Destination: rr.GetDestination(), Rate: rate}, nil
+
+	rate := 1.0
+	if cr.GetFrom() != cr.GetTo() {
+		rate, err = snap.Rate(cr.GetFrom(), cr.GetTo())
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+
+	return &protos.ConvertResponse{
+		Amount:     amount.Mul(decimal.NewFromFloat(rate)).String(),
+		From:       cr.GetFrom(),
+		To:         cr.GetTo(),
+		Rate:       rate,
+		SnapshotID: snap.ID,
+	}, nil
+}
+
+// ListCurrencies returns the currencies with a rate, currencies which are not
+// in the registry are left out as they can not be requested
+func (c *Currency) ListCurrencies(ctx context.Context, lr *protos.ListCurrenciesRequest) (*protos.ListCurrenciesResponse, error) {
+	c.logger(ctx).Info("Handle list currencies")
+
+	res := &protos.ListCurrenciesResponse{}
+	for _, ci := range c.rates.Currencies() {
+		rc, err := registry.Lookup(ci.Code)
+		if err != nil {
+			c.logger(ctx).Warn("Rate for unsupported currency", "currency", ci.Code, "source", ci.Source)
+			continue
+		}
+
+		res.Currencies = append(res.Currencies, &protos.CurrencyInfo{
+			Code:    rc.Code,
+			Name:    rc.Name,
+			Symbol:  rc.Symbol,
+			Updated: ci.Updated.UTC().Format(time.RFC3339),
+			Source:  ci.Source,
+		})
+	}
+
+	return res, nil
+}
+
+// snapshot returns the snapshot with the given id or the latest snapshot when
+// id is empty
+func (c *Currency) snapshot(id string) (*data.Snapshot, error) {
+	if id == "" {
+		return c.rates.Snapshot(), nil
+	}
+
+	snap, err := c.rates.SnapshotByID(id)
+	if err == data.ErrSnapshotNotFound {
+		return nil, status.Errorf(codes.NotFound, "Snapshot %s not found, it may have expired", id)
+	}
+
+	return snap, err
 }
 
+// GetHistoricalRate returns the rate between two currencies published on, or
+// most recently before, the requested date
+func (c *Currency) GetHistoricalRate(ctx context.Context, hr *protos.HistoricalRateRequest) (*protos.HistoricalRateResponse, error) {
+	c.logger(ctx).Info("Handle get historical rate", "base", hr.GetBase(), "destination", hr.GetDestination(), "date", hr.GetDate())
+
+	if s := validatePair(hr.GetBase(), hr.GetDestination(), hr); s != nil {
+		return nil, s.Err()
+	}
+
+	date, err := time.Parse(data.DateFormat, hr.GetDate())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Date %q must be in the format YYYY-MM-DD", hr.GetDate())
+	}
+
+	rate, published, err := c.rates.GetHistoricalRate(hr.GetBase(), hr.GetDestination(), date)
+	if err == data.ErrNoHistory {
+		return nil, status.Errorf(codes.NotFound, "No rates available on or before %s", hr.GetDate())
+	}
+	if err != nil {
+		return nil, withDetails(status.New(codes.NotFound, err.Error()), hr).Err()
+	}
+
+	return &protos.HistoricalRateResponse{
+		Base:        hr.GetBase(),
+		Destination: hr.GetDestination(),
+		Rate:        rate,
+		Date:        published.Format(data.DateFormat),
+	}, nil
+}
+
+// SubscribeRates streams updated rates for the currency pairs sent by the
+// client, an update is only sent when the rate for a pair changes
 func (c *Currency) SubscribeRates(srs protos.Currency_SubscribeRatesServer) error {
+	sub := c.hub.Register(srs)
+	defer c.hub.Unregister(sub)
+
+	ctx, cancel := context.WithCancel(srs.Context())
+	defer cancel()
+
 	// Handles Client Messages
-	for {
-		rr, err := srs.Recv()
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- c.handleClientMessages(srs, sub)
+	}()
+
+	// Sends updates to the client
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- sub.Run(ctx)
+	}()
+
+	select {
+	case err := <-recvErr:
 		if err == io.EOF {
 			c.log.Info("Client has closed connection")
+			return nil
+		}
+		return err
+	case err := <-sendErr:
+		if err != nil {
+			c.log.Error("Unable to send updated rate", "error", err)
+		}
+		return err
+	case <-sub.Evicted():
+		return status.Error(codes.ResourceExhausted, "Subscriber is not receiving updates fast enough")
+	}
+}
+
+// handleClientMessages applies the subscription messages sent by the client
+// until the client closes the stream
+func (c *Currency) handleClientMessages(srs protos.Currency_SubscribeRatesServer, sub *Subscriber) error {
+	for {
+		req, err := srs.Recv()
+		if err == io.EOF {
 			return err
 		}
 		if err != nil {
 			c.log.Error("Unable to recieve from client", "error", err)
 			return err
 		}
-		c.log.Info("Handle client request from client", "request", rr)
-		rrs, ok := c.subscriptions[srs]
-		if !ok {
-			rrs = []*protos.RateRequest{}
+
+		c.log.Info("Handle client request from client", "request", req)
+
+		switch m := req.GetMessage().(type) {
+		case *protos.SubscribeRatesRequest_Subscribe:
+			rr := m.Subscribe
+
+			if s := validateRateRequest(rr); s != nil {
+				c.log.Error("Invalid subscription", "base", rr.GetBase(), "destination", rr.GetDestination(), "error", s.Message())
+				c.hub.Reply(sub, errorResponse(s))
+				continue
+			}
+
+			added, err := sub.Subscribe(rr, c.rates.Snapshot())
+			if err != nil {
+				c.log.Error("Unable to subscribe to rate", "base", rr.GetBase(), "destination", rr.GetDestination(), "error", err)
+				c.hub.Reply(sub, errorResponse(withDetails(status.New(codes.NotFound, err.Error()), rr)))
+				continue
+			}
+
+			result := protos.SubscriptionAck_SUBSCRIBED
+			if !added {
+				result = protos.SubscriptionAck_ALREADY_SUBSCRIBED
+			}
+			c.hub.Reply(sub, ackResponse(rr, result))
+
+		case *protos.SubscribeRatesRequest_Unsubscribe:
+			rr := m.Unsubscribe
+
+			result := protos.SubscriptionAck_UNSUBSCRIBED
+			if !sub.Unsubscribe(rr) {
+				result = protos.SubscriptionAck_NOT_SUBSCRIBED
+			}
+			c.hub.Reply(sub, ackResponse(rr, result))
+
+		case *protos.SubscribeRatesRequest_List:
+			c.hub.Reply(sub, &protos.SubscribeRatesResponse{
+				Message: &protos.SubscribeRatesResponse_Subscriptions{
+					Subscriptions: &protos.SubscriptionList{Subscriptions: sub.Subscriptions()},
+				},
+			})
+
+		default:
+			c.log.Error("Unknown message from client", "request", req)
+		}
+	}
+}
+
+// ackResponse acknowledges a Subscribe or Unsubscribe message
+func ackResponse(rr *protos.RateRequest, result protos.SubscriptionAck_Action) *protos.SubscribeRatesResponse {
+	return &protos.SubscribeRatesResponse{
+		Message: &protos.SubscribeRatesResponse_Ack{
+			Ack: &protos.SubscriptionAck{Result: result, Request: rr},
+		},
+	}
+}
+
+// errorResponse reports an error with a message sent by the client
+func errorResponse(s *status.Status) *protos.SubscribeRatesResponse {
+	return &protos.SubscribeRatesResponse{
+		Message: &protos.SubscribeRatesResponse_Error{Error: s.Proto()},
+	}
+}
+
+// validateRateRequest returns an InvalidArgument status when the request is
+// invalid, otherwise nil
+func validateRateRequest(rr *protos.RateRequest) *status.Status {
+	return validatePair(rr.GetBase(), rr.GetDestination(), rr)
+}
+
+// validatePair returns an InvalidArgument status, with the request in the
+// details, when either currency is unknown or they are the same
+func validatePair(base, dest string, req proto.Message) *status.Status {
+	for _, code := range []string{base, dest} {
+		if _, err := registry.Lookup(code); err != nil {
+			return withDetails(status.New(codes.InvalidArgument, err.Error()), req)
 		}
-		rrs = append(rrs, rr)
-		c.subscriptions[srs] = rrs
 	}
-	return nil
+
+	if base != dest {
+		return nil
+	}
+
+	s := status.Newf(
+		codes.InvalidArgument,
+		"Base currency %s can not be the same as the destination currency %s",
+		base,
+		dest,
+	)
+
+	return withDetails(s, req)
+}
+
+// withDetails adds the request to the details of the status so the client can
+// tell which request failed
+func withDetails(s *status.Status, req proto.Message) *status.Status {
+	ds, err := s.WithDetails(req)
+	if err != nil {
+		return s
+	}
+
+	return ds
 }
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newTestCurrency(t *testing.T) *Currency {
+	r, err := data.NewRates(hclog.NewNullLogger(), data.NewStaticProvider(map[string]float64{"USD": 1.18, "GBP": 0.86}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Currency{rates: r, log: hclog.NewNullLogger(), hub: NewHub(hclog.NewNullLogger(), DefaultQueueSize)}
+}
+
+func subscribe(base, dest string) *protos.SubscribeRatesRequest {
+	return &protos.SubscribeRatesRequest{
+		Message: &protos.SubscribeRatesRequest_Subscribe{
+			Subscribe: &protos.RateRequest{Base: base, Destination: dest},
+		},
+	}
+}
+
+func TestGetRateSameCurrencyIsInvalid(t *testing.T) {
+	c := newTestCurrency(t)
+
+	_, err := c.GetRate(context.Background(), &protos.RateRequest{Base: "USD", Destination: "USD"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument got %v", err)
+	}
+}
+
+func TestGetRateUnknownCurrencyIsInvalid(t *testing.T) {
+	c := newTestCurrency(t)
+
+	for _, code := range []string{"XYZ", "usd", ""} {
+		_, err := c.GetRate(context.Background(), &protos.RateRequest{Base: "EUR", Destination: code})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for %q got %v", code, err)
+		}
+	}
+}
+
+func TestConvertUnknownCurrencyIsInvalid(t *testing.T) {
+	c := newTestCurrency(t)
+
+	_, err := c.Convert(context.Background(), &protos.ConvertRequest{Amount: "1", From: "XYZ", To: "XYZ"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument got %v", err)
+	}
+}
+
+func TestStreamReportsInvalidSubscriptions(t *testing.T) {
+	c := newTestCurrency(t)
+	fs := &fakeStream{recv: []*protos.SubscribeRatesRequest{
+		subscribe("EUR", "EUR"),
+		subscribe("EUR", "JPY"),
+		subscribe("EUR", "USD"),
+	}}
+	sub := c.hub.Register(fs)
+
+	err := c.handleClientMessages(fs, sub)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF got %v", err)
+	}
+
+	if len(sub.queue) != 3 {
+		t.Fatalf("expected 3 responses got %d", len(sub.queue))
+	}
+
+	// base and destination are the same
+	se := (<-sub.queue).GetError()
+	if codes.Code(se.GetCode()) != codes.InvalidArgument || len(se.GetDetails()) != 1 {
+		t.Fatalf("expected InvalidArgument error with details got %v", se)
+	}
+
+	// no rate for the destination
+	se = (<-sub.queue).GetError()
+	if codes.Code(se.GetCode()) != codes.NotFound {
+		t.Fatalf("expected NotFound error got %v", se)
+	}
+
+	ack := (<-sub.queue).GetAck()
+	if ack.GetResult() != protos.SubscriptionAck_SUBSCRIBED {
+		t.Fatalf("expected subscribed ack got %v", ack)
+	}
+
+	if len(sub.Subscriptions()) != 1 {
+		t.Fatalf("expected 1 subscription got %d", len(sub.Subscriptions()))
+	}
+}
+
+func TestGetRatesUsesOneSnapshot(t *testing.T) {
+	c := newTestCurrency(t)
+
+	res, err := c.GetRates(context.Background(), &protos.RatesRequest{
+		Base:         "EUR",
+		Destinations: []string{"USD", "GBP"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.GetRates()) != 2 || res.GetRates()[0].GetRate() != 1.18 || res.GetRates()[1].GetRate() != 0.86 {
+		t.Fatalf("unexpected rates %v", res.GetRates())
+	}
+
+	for _, r := range res.GetRates() {
+		if r.GetSnapshotID() != res.GetSnapshotID() {
+			t.Fatalf("expected snapshot %s got %s", res.GetSnapshotID(), r.GetSnapshotID())
+		}
+	}
+
+	_, err = c.GetRates(context.Background(), &protos.RatesRequest{
+		Base:         "EUR",
+		Destinations: []string{"USD"},
+		SnapshotID:   "expired",
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown snapshot got %v", err)
+	}
+
+	_, err = c.GetRates(context.Background(), &protos.RatesRequest{Base: "EUR"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without destinations got %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	c := newTestCurrency(t)
+	snap := c.rates.Snapshot()
+
+	res, err := c.Convert(context.Background(), &protos.ConvertRequest{
+		Amount:     "2.45",
+		From:       "EUR",
+		To:         "USD",
+		SnapshotID: snap.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.GetAmount() != "2.891" || res.GetSnapshotID() != snap.ID {
+		t.Fatalf("unexpected conversion %v", res)
+	}
+
+	_, err = c.Convert(context.Background(), &protos.ConvertRequest{Amount: "abc", From: "EUR", To: "USD"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an invalid amount got %v", err)
+	}
+}
+
+func TestListCurrencies(t *testing.T) {
+	c := newTestCurrency(t)
+
+	res, err := c.ListCurrencies(context.Background(), &protos.ListCurrenciesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, ci := range res.GetCurrencies() {
+		got = append(got, ci.GetCode())
+	}
+	if strings.Join(got, ",") != "EUR,GBP,USD" {
+		t.Fatalf("expected EUR,GBP,USD got %v", got)
+	}
+
+	gbp := res.GetCurrencies()[1]
+	if gbp.GetName() != "Pound Sterling" || gbp.GetSymbol() != "£" || gbp.GetSource() != "static" {
+		t.Fatalf("unexpected currency %v", gbp)
+	}
+
+	if _, err := time.Parse(time.RFC3339, gbp.GetUpdated()); err != nil {
+		t.Fatalf("expected RFC 3339 update time got %q", gbp.GetUpdated())
+	}
+}
+
+func TestCallsAreLoggedWithRequestID(t *testing.T) {
+	c := newTestCurrency(t)
+
+	buf := &strings.Builder{}
+	c.log = hclog.New(&hclog.LoggerOptions{Output: buf})
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataRequestID, "abc-123"))
+	if _, err := c.GetRate(ctx, &protos.RateRequest{Base: "EUR", Destination: "USD"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "request_id=abc-123") {
+		t.Fatalf("expected the request id to be logged got %q", buf.String())
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServiceName is the full name of the Currency service used in health checks
+const ServiceName = "currency.v2.Currency"
+
+// NewHealth returns a grpc.health.v1 service which reports the server, and
+// the Currency service, as NOT_SERVING until the rates have been loaded
+func NewHealth(r *data.ExchangeRates) *health.Server {
+	hs := health.NewServer()
+	setServingStatus(hs, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	go func() {
+		<-r.Loaded()
+		setServingStatus(hs, healthpb.HealthCheckResponse_SERVING)
+	}()
+
+	return hs
+}
+
+// setServingStatus sets the status of the server and the Currency service
+func setServingStatus(hs *health.Server, s healthpb.HealthCheckResponse_ServingStatus) {
+	for _, name := range []string{"", ServiceName} {
+		hs.SetServingStatus(name, s)
+	}
+}
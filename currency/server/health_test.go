@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
+	"github.com/hashicorp/go-hclog"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// failingProvider is a RateProvider which is never able to load the rates
+type failingProvider struct{}
+
+func (failingProvider) Name() string {
+	return "failing"
+}
+
+func (failingProvider) Latest() (*data.RateSnapshot, error) {
+	return nil, fmt.Errorf("Unable to reach provider")
+}
+
+func servingStatus(t *testing.T, h healthpb.HealthServer, service string) healthpb.HealthCheckResponse_ServingStatus {
+	res, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res.GetStatus()
+}
+
+func TestHealthNotServingUntilRatesLoaded(t *testing.T) {
+	r, err := data.NewRates(hclog.NewNullLogger(), failingProvider{})
+	if err == nil {
+		t.Fatal("expected error loading rates")
+	}
+
+	hs := NewHealth(r)
+	for _, s := range []string{"", ServiceName} {
+		if st := servingStatus(t, hs, s); st != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Fatalf("expected NOT_SERVING for %q got %s", s, st)
+		}
+	}
+}
+
+func TestHealthServingOnceRatesLoaded(t *testing.T) {
+	c := newTestCurrency(t)
+	hs := NewHealth(c.rates)
+
+	deadline := time.Now().Add(time.Second)
+	for servingStatus(t, hs, ServiceName) != healthpb.HealthCheckResponse_SERVING {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for SERVING")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultQueueSize is the number of updates buffered for each subscriber
+// before it is considered a slow consumer and evicted
+const DefaultQueueSize = 64
+
+// Hub fans rate updates out to the streams subscribed to them
+// Hub is safe for concurrent use
+type Hub struct {
+	log       hclog.Logger
+	queueSize int
+
+	mu          sync.RWMutex
+	subscribers map[*Subscriber]struct{}
+}
+
+// NewHub creates a Hub which buffers queueSize updates for each subscriber
+func NewHub(l hclog.Logger, queueSize int) *Hub {
+	return &Hub{log: l, queueSize: queueSize, subscribers: map[*Subscriber]struct{}{}}
+}
+
+// Subscriber is a client stream registered with the Hub
+type Subscriber struct {
+	stream protos.Currency_SubscribeRatesServer
+	queue  chan *protos.SubscribeRatesResponse
+
+	// evicted is closed when the Hub removes a slow subscriber
+	evicted   chan struct{}
+	evictOnce sync.Once
+
+	mu    sync.Mutex
+	pairs map[string]*protos.RateRequest
+	last  map[string]float64
+}
+
+// Register adds a stream to the Hub, the caller must call Unregister when
+// the stream ends
+func (h *Hub) Register(srs protos.Currency_SubscribeRatesServer) *Subscriber {
+	s := &Subscriber{
+		stream:  srs,
+		queue:   make(chan *protos.SubscribeRatesResponse, h.queueSize),
+		evicted: make(chan struct{}),
+		pairs:   map[string]*protos.RateRequest{},
+		last:    map[string]float64{},
+	}
+
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	subscribersGauge.Set(float64(len(h.subscribers)))
+	h.mu.Unlock()
+
+	return s
+}
+
+// Unregister removes a stream from the Hub, no more updates are queued for it
+func (h *Hub) Unregister(s *Subscriber) {
+	h.mu.Lock()
+	delete(h.subscribers, s)
+	subscribersGauge.Set(float64(len(h.subscribers)))
+	h.mu.Unlock()
+}
+
+// Len returns the number of registered subscribers
+func (h *Hub) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.subscribers)
+}
+
+// Publish queues the rate from the snapshot for every subscribed pair which
+// has changed since it was last sent. Subscribers whose queue is full are evicted
+func (h *Hub) Publish(snap *data.Snapshot) {
+	slow := []*Subscriber{}
+
+	h.mu.RLock()
+	for s := range h.subscribers {
+		if !s.publish(h.log, snap) {
+			slow = append(slow, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, s := range slow {
+		h.evict(s)
+	}
+}
+
+// Reply queues a response to a message sent by the subscriber, the
+// subscriber is evicted when its queue is full
+func (h *Hub) Reply(s *Subscriber, res *protos.SubscribeRatesResponse) {
+	select {
+	case s.queue <- res:
+	default:
+		h.evict(s)
+	}
+}
+
+func (h *Hub) evict(s *Subscriber) {
+	h.log.Warn("Evicting slow subscriber", "queue_size", h.queueSize)
+
+	h.Unregister(s)
+	s.evict()
+}
+
+// Subscribe adds the pair in the request to the subscription, the rate in the
+// snapshot is recorded so only later changes are sent. Returns false when the
+// pair was already subscribed, in which case nothing changes
+func (s *Subscriber) Subscribe(rr *protos.RateRequest, snap *data.Snapshot) (bool, error) {
+	k := pairKey(rr)
+
+	s.mu.Lock()
+	_, ok := s.pairs[k]
+	s.mu.Unlock()
+	if ok {
+		return false, nil
+	}
+
+	r, err := snap.Rate(rr.GetBase(), rr.GetDestination())
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// subscribed by a concurrent call while the rate was fetched
+	if _, ok := s.pairs[k]; ok {
+		return false, nil
+	}
+
+	s.pairs[k] = rr
+	s.last[k] = r
+
+	return true, nil
+}
+
+// Unsubscribe removes the pair in the request from the subscription, returns
+// false when the pair was not subscribed
+func (s *Subscriber) Unsubscribe(rr *protos.RateRequest) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := pairKey(rr)
+	if _, ok := s.pairs[k]; !ok {
+		return false
+	}
+
+	delete(s.pairs, k)
+	delete(s.last, k)
+
+	return true
+}
+
+// Subscriptions returns the subscribed pairs ordered by base then destination code
+func (s *Subscriber) Subscriptions() []*protos.RateRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rrs := []*protos.RateRequest{}
+	for _, rr := range s.pairs {
+		rrs = append(rrs, &protos.RateRequest{Base: rr.GetBase(), Destination: rr.GetDestination()})
+	}
+
+	sort.Slice(rrs, func(i, j int) bool {
+		return pairKey(rrs[i]) < pairKey(rrs[j])
+	})
+
+	return rrs
+}
+
+// Evicted returns a channel which is closed when the subscriber is evicted
+func (s *Subscriber) Evicted() <-chan struct{} {
+	return s.evicted
+}
+
+// Run sends queued updates to the stream until the context is done, the
+// subscriber is evicted or sending fails
+func (s *Subscriber) Run(ctx context.Context) error {
+	for {
+		select {
+		case rr := <-s.queue:
+			err := s.stream.Send(rr)
+			if err != nil {
+				return err
+			}
+		case <-s.evicted:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// publish queues the changed rates, returns false when the queue is full
+func (s *Subscriber) publish(l hclog.Logger, snap *data.Snapshot) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, rr := range s.pairs {
+		r, err := snap.Rate(rr.GetBase(), rr.GetDestination())
+		if err != nil {
+			l.Error("Unable to get updated rate", "base", rr.GetBase(), "destination", rr.GetDestination(), "error", err)
+			continue
+		}
+
+		if r == s.last[k] {
+			continue
+		}
+
+		select {
+		case s.queue <- rateResponse(rr, r, snap.ID):
+			s.last[k] = r
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Subscriber) evict() {
+	s.evictOnce.Do(func() { close(s.evicted) })
+}
+
+// pairKey identifies a currency pair in a subscription
+func pairKey(rr *protos.RateRequest) string {
+	return rr.GetBase() + "/" + rr.GetDestination()
+}
+
+// rateResponse wraps an updated rate for sending on the stream
+func rateResponse(rr *protos.RateRequest, rate float64, snapshotID string) *protos.SubscribeRatesResponse {
+	return &protos.SubscribeRatesResponse{
+		Message: &protos.SubscribeRatesResponse_RateResponse{
+			RateResponse: &protos.RateResponse{Base: rr.GetBase(), Destination: rr.GetDestination(), Rate: rate, SnapshotID: snapshotID},
+		},
+	}
+}
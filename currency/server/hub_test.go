@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/data"
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+)
+
+// fakeStream is a SubscribeRates stream which records the sent responses
+// and receives the given requests followed by io.EOF
+type fakeStream struct {
+	grpc.ServerStream
+	sent chan *protos.SubscribeRatesResponse
+	recv []*protos.SubscribeRatesRequest
+}
+
+func (f *fakeStream) Send(rr *protos.SubscribeRatesResponse) error {
+	f.sent <- rr
+	return nil
+}
+
+func (f *fakeStream) Recv() (*protos.SubscribeRatesRequest, error) {
+	if len(f.recv) == 0 {
+		return nil, io.EOF
+	}
+
+	req := f.recv[0]
+	f.recv = f.recv[1:]
+	return req, nil
+}
+
+func (f *fakeStream) Context() context.Context {
+	return context.Background()
+}
+
+// snapshot returns a snapshot with the given EUR to USD rate
+func snapshot(usd float64) *data.Snapshot {
+	return data.NewSnapshot("test", time.Now(), map[string]float64{"EUR": 1, "USD": usd, "GBP": 0.86})
+}
+
+func usdRequest() *protos.RateRequest {
+	return &protos.RateRequest{Base: "EUR", Destination: "USD"}
+}
+
+func TestHubOnlyPublishesChangedRates(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 4)
+	s := h.Register(&fakeStream{})
+
+	usd := 1.1
+	if _, err := s.Subscribe(usdRequest(), snapshot(usd)); err != nil {
+		t.Fatal(err)
+	}
+
+	h.Publish(snapshot(usd))
+	if len(s.queue) != 0 {
+		t.Fatalf("expected no updates for an unchanged rate got %d", len(s.queue))
+	}
+
+	usd = 1.2
+	h.Publish(snapshot(usd))
+	h.Publish(snapshot(usd))
+	if len(s.queue) != 1 {
+		t.Fatalf("expected 1 update got %d", len(s.queue))
+	}
+
+	rr := (<-s.queue).GetRateResponse()
+	if rr.GetRate() != 1.2 {
+		t.Fatalf("expected rate 1.2 got %f", rr.GetRate())
+	}
+}
+
+func TestHubEvictsSlowSubscriber(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 1)
+	s := h.Register(&fakeStream{})
+
+	usd := 1.1
+	if _, err := s.Subscribe(usdRequest(), snapshot(usd)); err != nil {
+		t.Fatal(err)
+	}
+
+	usd = 1.2
+	h.Publish(snapshot(usd))
+	usd = 1.3
+	h.Publish(snapshot(usd))
+
+	select {
+	case <-s.Evicted():
+	default:
+		t.Fatal("expected the subscriber to be evicted")
+	}
+
+	if h.Len() != 0 {
+		t.Fatalf("expected evicted subscriber to be unregistered got %d subscribers", h.Len())
+	}
+}
+
+func TestSubscriberRunSendsQueuedUpdates(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 4)
+	fs := &fakeStream{sent: make(chan *protos.SubscribeRatesResponse, 1)}
+	s := h.Register(fs)
+
+	usd := 1.1
+	if _, err := s.Subscribe(usdRequest(), snapshot(usd)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	usd = 1.2
+	h.Publish(snapshot(usd))
+
+	rr := (<-fs.sent).GetRateResponse()
+	if rr.GetDestination() != "USD" || rr.GetRate() != 1.2 {
+		t.Fatalf("unexpected update %v", rr)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected context.Canceled got %v", err)
+	}
+}
+
+func TestHubUnregister(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 4)
+	s := h.Register(&fakeStream{})
+
+	if h.Len() != 1 {
+		t.Fatalf("expected 1 subscriber got %d", h.Len())
+	}
+
+	if g := testutil.ToFloat64(subscribersGauge); g != 1 {
+		t.Fatalf("expected subscribers metric 1 got %f", g)
+	}
+
+	// unregistering twice, as happens after an eviction, is safe
+	h.Unregister(s)
+	h.Unregister(s)
+	if h.Len() != 0 {
+		t.Fatalf("expected 0 subscribers got %d", h.Len())
+	}
+
+	if g := testutil.ToFloat64(subscribersGauge); g != 0 {
+		t.Fatalf("expected subscribers metric 0 got %f", g)
+	}
+}
+
+func TestSubscribeIsIdempotent(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 4)
+	s := h.Register(&fakeStream{})
+
+	usd := 1.1
+	added, err := s.Subscribe(usdRequest(), snapshot(usd))
+	if err != nil || !added {
+		t.Fatalf("expected pair to be added, added: %v, error: %v", added, err)
+	}
+
+	added, err = s.Subscribe(usdRequest(), snapshot(usd))
+	if err != nil || added {
+		t.Fatalf("expected duplicate pair not to be added, added: %v, error: %v", added, err)
+	}
+
+	// a single update is sent for the pair
+	usd = 1.2
+	h.Publish(snapshot(usd))
+	if len(s.queue) != 1 {
+		t.Fatalf("expected 1 update got %d", len(s.queue))
+	}
+}
+
+func TestUnsubscribeStopsUpdates(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 4)
+	s := h.Register(&fakeStream{})
+
+	usd := 1.1
+	if _, err := s.Subscribe(usdRequest(), snapshot(usd)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.Unsubscribe(usdRequest()) {
+		t.Fatal("expected subscribed pair to be removed")
+	}
+	if s.Unsubscribe(usdRequest()) {
+		t.Fatal("expected removing an unsubscribed pair to return false")
+	}
+
+	usd = 1.2
+	h.Publish(snapshot(usd))
+	if len(s.queue) != 0 {
+		t.Fatalf("expected no updates after unsubscribing got %d", len(s.queue))
+	}
+}
+
+func TestSubscriptionsAreOrdered(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 4)
+	s := h.Register(&fakeStream{})
+
+	usd := 1.1
+	pairs := []*protos.RateRequest{
+		{Base: "EUR", Destination: "GBP"},
+		{Base: "EUR", Destination: "USD"},
+		{Base: "USD", Destination: "EUR"},
+	}
+	for _, i := range []int{2, 0, 1} {
+		if _, err := s.Subscribe(pairs[i], snapshot(usd)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	subs := s.Subscriptions()
+	if len(subs) != len(pairs) {
+		t.Fatalf("expected %d subscriptions got %d", len(pairs), len(subs))
+	}
+	for i := range pairs {
+		if pairKey(subs[i]) != pairKey(pairs[i]) {
+			t.Fatalf("expected %s at %d got %s", pairKey(pairs[i]), i, pairKey(subs[i]))
+		}
+	}
+}
+
+func TestReplyEvictsWhenQueueIsFull(t *testing.T) {
+	h := NewHub(hclog.NewNullLogger(), 1)
+	s := h.Register(&fakeStream{})
+
+	h.Reply(s, ackResponse(usdRequest(), protos.SubscriptionAck_SUBSCRIBED))
+	h.Reply(s, ackResponse(usdRequest(), protos.SubscriptionAck_SUBSCRIBED))
+
+	select {
+	case <-s.Evicted():
+	default:
+		t.Fatal("expected the subscriber to be evicted")
+	}
+}
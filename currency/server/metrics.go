@@ -0,0 +1,20 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metrics for the rate stream, the gRPC calls are measured by interceptors
+var (
+	subscribersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "currency_rate_subscribers",
+		Help: "Number of SubscribeRates streams registered with the hub",
+	})
+
+	fanOutSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "currency_rate_fanout_seconds",
+		Help:    "Time taken to queue updated rates for every subscriber",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+	})
+)
@@ -0,0 +1,8 @@
+module github.com/JamieBShaw/golang-mux-rest-api/health
+
+go 1.14
+
+require (
+	github.com/hashicorp/go-hclog v0.14.1
+	github.com/stretchr/testify v1.6.1
+)
@@ -0,0 +1,71 @@
+// Package health provides the liveness and readiness probes shared by the
+// HTTP services. A service is live while it is running and ready when all of
+// the checks for its dependencies pass
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Check reports whether a dependency of the service is ready, a nil error
+// means the dependency is ready
+type Check func() error
+
+// Handler is a handler for the liveness and readiness probes
+type Handler struct {
+	log    hclog.Logger
+	checks map[string]Check
+}
+
+// NewHandler creates a new Handler, the service is ready when all of the
+// named checks pass
+func NewHandler(l hclog.Logger, checks map[string]Check) *Handler {
+	return &Handler{log: l, checks: checks}
+}
+
+// Status is the result of a health check
+// swagger:model HealthStatus
+type Status struct {
+	// ok when the service is healthy, otherwise unavailable
+	Status string `json:"status"`
+
+	// the reason each failing check is not ready
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Live returns 200 while the service is running
+func (h *Handler) Live(rw http.ResponseWriter, r *http.Request) {
+	h.write(rw, http.StatusOK, &Status{Status: "ok"})
+}
+
+// Ready returns 200 when the service can handle requests, otherwise 503 with
+// the reason each failing check is not ready
+func (h *Handler) Ready(rw http.ResponseWriter, r *http.Request) {
+	failed := map[string]string{}
+	for name, check := range h.checks {
+		if err := check(); err != nil {
+			failed[name] = err.Error()
+		}
+	}
+
+	if len(failed) > 0 {
+		h.log.Warn("Service is not ready", "checks", failed)
+		h.write(rw, http.StatusServiceUnavailable, &Status{Status: "unavailable", Checks: failed})
+		return
+	}
+
+	h.write(rw, http.StatusOK, &Status{Status: "ok"})
+}
+
+func (h *Handler) write(rw http.ResponseWriter, code int, s *Status) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+
+	err := json.NewEncoder(rw).Encode(s)
+	if err != nil {
+		h.log.Error("Unable to serialize health status", "error", err)
+	}
+}
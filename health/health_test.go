@@ -0,0 +1,59 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// probe calls the handler and decodes the status it returns
+func probe(t *testing.T, hf http.HandlerFunc) (int, *Status) {
+	rw := httptest.NewRecorder()
+	hf(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+
+	s := &Status{}
+	assert.NoError(t, json.NewDecoder(rw.Body).Decode(s))
+
+	return rw.Code, s
+}
+
+func TestLiveIgnoresChecks(t *testing.T) {
+	h := NewHandler(hclog.NewNullLogger(), map[string]Check{
+		"storage": func() error { return fmt.Errorf("disk full") },
+	})
+
+	code, s := probe(t, h.Live)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", s.Status)
+}
+
+func TestReadyWhenAllChecksPass(t *testing.T) {
+	h := NewHandler(hclog.NewNullLogger(), map[string]Check{
+		"storage":  func() error { return nil },
+		"currency": func() error { return nil },
+	})
+
+	code, s := probe(t, h.Ready)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", s.Status)
+	assert.Empty(t, s.Checks)
+}
+
+func TestNotReadyReturnsFailingChecks(t *testing.T) {
+	h := NewHandler(hclog.NewNullLogger(), map[string]Check{
+		"storage":  func() error { return nil },
+		"currency": func() error { return fmt.Errorf("connection is TRANSIENT_FAILURE") },
+	})
+
+	code, s := probe(t, h.Ready)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", s.Status)
+	assert.Equal(t, map[string]string{"currency": "connection is TRANSIENT_FAILURE"}, s.Checks)
+}
@@ -0,0 +1,12 @@
+module github.com/JamieBShaw/golang-mux-rest-api/metrics
+
+go 1.14
+
+require (
+	github.com/JamieBShaw/golang-mux-rest-api/requestlog v0.0.0
+	github.com/gorilla/mux v1.8.0
+	github.com/prometheus/client_golang v1.7.1
+	github.com/stretchr/testify v1.6.1
+)
+
+replace github.com/JamieBShaw/golang-mux-rest-api/requestlog => ../requestlog
@@ -0,0 +1,44 @@
+// Package metrics records Prometheus metrics for the HTTP requests handled by
+// the services. Every service uses the same metric names, labels and buckets
+// so their requests can be compared on the same dashboards
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metrics for the HTTP requests, labelled by the route template so the ids in
+// the paths do not create a series per id
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled by route, method and status code",
+	}, []string{"route", "method", "code"})
+
+	httpRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests by route and method",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+// Middleware records the count and latency of requests to the matched route,
+// it must be used on the router so the route is known
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rr := requestlog.NewResponseRecorder(rw)
+
+		next.ServeHTTP(rr, r)
+
+		route := requestlog.RouteTemplate(r)
+		httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(rr.Status)).Inc()
+		httpRequestSeconds.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareLabelsByRouteTemplate(t *testing.T) {
+	sm := mux.NewRouter()
+	sm.HandleFunc("/products/{id:[0-9]+}", func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	})
+	sm.Use(Middleware)
+
+	route := "/products/{id:[0-9]+}"
+	before := testutil.ToFloat64(httpRequests.WithLabelValues(route, http.MethodGet, "404"))
+
+	for _, path := range []string{"/products/1", "/products/2"} {
+		sm.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	after := testutil.ToFloat64(httpRequests.WithLabelValues(route, http.MethodGet, "404"))
+	assert.Equal(t, 2.0, after-before)
+}
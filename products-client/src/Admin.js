@@ -7,7 +7,7 @@ import Container from 'react-bootstrap/Container';
 import Toast from './Toast.js';
 
 import axios from 'axios';
-import { files_location } from './api.js';
+import { files_location, authHeaders } from './api.js';
 
 /*
 This is the react equivilent of the following HTML form
@@ -55,9 +55,7 @@ class Admin extends React.Component {
 
     // upload the file
     axios
-      .post(files_location, data, {
-        'content-type': `multipart/form-data; boundary=${data._boundary}`,
-      })
+      .post(files_location, data, { headers: authHeaders() })
       .then((res) => {
         console.log(res);
         var toastText = '';
@@ -144,12 +142,13 @@ class Admin extends React.Component {
                 <Form.Control
                   type="file"
                   name="file"
+                  accept="image/png,image/jpeg,image/gif"
                   placeholder=""
                   required
                   onChange={this.changeHandler}
                 />
                 <Form.Text className="text-muted">
-                  Image to associate with the product
+                  PNG, JPEG or GIF image to associate with the product
                 </Form.Text>
                 <Form.Control.Feedback type="invalid">
                   Please select a file to upload.
@@ -1,12 +1,34 @@
 import React from 'react';
 import Table from 'react-bootstrap/Table';
+import Form from 'react-bootstrap/Form';
 import axios from 'axios';
-import { api_location } from './api';
+import { api_location, files_location } from './api';
+
+// formatPrice displays a price returned by the API, e.g. {amount: 2.45, currency: 'EUR'},
+// using the number of decimal places for the currency
+function formatPrice(price) {
+  return new Intl.NumberFormat(undefined, {
+    style: 'currency',
+    currency: price.currency,
+  }).format(price.amount);
+}
+
+// thumbnailURL returns the thumbnail the image service creates when an image
+// is uploaded for the product
+function thumbnailURL(product) {
+  return files_location + '/images/' + product.id + '/thumbnail.jpg';
+}
+
+// hideImage hides products without an image instead of showing a broken image
+function hideImage(event) {
+  event.target.style.visibility = 'hidden';
+}
+
 class CoffeeList extends React.Component {
-  readData() {
+  readData(currency) {
     const self = this;
     axios
-      .get(api_location + '/products')
+      .get(api_location + '/products', { params: currency ? { currency } : {} })
       .then(function(response) {
         console.log(response.data);
 
@@ -17,14 +39,53 @@ class CoffeeList extends React.Component {
       });
   }
 
+  // readCurrencies fills the currency picker with the currencies supported
+  // by the API
+  readCurrencies() {
+    const self = this;
+    axios
+      .get(api_location + '/currencies')
+      .then(function(response) {
+        self.setState({ currencies: response.data });
+      })
+      .catch(function(error) {
+        console.log(error);
+      });
+  }
+
+  changeCurrency(event) {
+    this.setState({ currency: event.target.value });
+    this.readData(event.target.value);
+  }
+
+  // getCurrencies returns the options for the currency picker, EUR is left
+  // out as prices are returned in EUR when no currency is given
+  getCurrencies() {
+    return this.state.currencies
+      .filter(c => c.code !== 'EUR')
+      .map(c => (
+        <option key={c.code} value={c.code}>
+          {c.code} - {c.name}
+        </option>
+      ));
+  }
+
   getProducts() {
     let table = [];
 
     for (let i = 0; i < this.state.products.length; i++) {
       table.push(
         <tr key={i}>
+          <td>
+            <img
+              src={thumbnailURL(this.state.products[i])}
+              alt={this.state.products[i].name}
+              width="80"
+              onError={hideImage}
+            />
+          </td>
           <td>{this.state.products[i].name}</td>
-          <td>{this.state.products[i].price}</td>
+          <td>{formatPrice(this.state.products[i].price)}</td>
           <td>{this.state.products[i].sku}</td>
         </tr>
       );
@@ -36,18 +97,32 @@ class CoffeeList extends React.Component {
   constructor(props) {
     super(props);
     this.readData();
-    this.state = { products: [] };
+    this.readCurrencies();
+    this.state = { products: [], currencies: [], currency: '' };
 
     this.readData = this.readData.bind(this);
+    this.changeCurrency = this.changeCurrency.bind(this);
   }
 
   render() {
     return (
       <div>
         <h1 style={{ marginBottom: '40px' }}>Menu</h1>
+        <Form.Group controlId="currency">
+          <Form.Label>Currency</Form.Label>
+          <Form.Control
+            as="select"
+            value={this.state.currency}
+            onChange={this.changeCurrency}
+          >
+            <option value="">EUR - Euro</option>
+            {this.getCurrencies()}
+          </Form.Control>
+        </Form.Group>
         <Table>
           <thead>
             <tr>
+              <th></th>
               <th>Name</th>
               <th>Price</th>
               <th>SKU</th>
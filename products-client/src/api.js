@@ -1,2 +1,8 @@
 export const api_location = 'http://localhost:9090';
 export const files_location = 'http://localhost:9091';
+
+// API key sent with uploads to the files service, set REACT_APP_API_KEY to a
+// key with the images:write scope
+export const api_key = process.env.REACT_APP_API_KEY;
+
+export const authHeaders = () => (api_key ? { 'X-API-Key': api_key } : {});
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-images/images"
+	"github.com/JamieBShaw/golang-mux-rest-api/ratelimit"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Config is the configuration of the image service, see config.Load for how it
+// is set. The environment variables have no prefix so an existing .env file
+// still applies
+type Config struct {
+	BindAddress      string        `config:"bind_address" default:":9091" usage:"address the service listens on"`
+	BasePath         string        `config:"base_path" default:"./imagestore" usage:"directory the images are stored in"`
+	MaxFileSize      int           `config:"max_file_size" default:"5120000" usage:"largest image that can be stored in bytes"`
+	CORSOrigins      []string      `config:"cors_origins" default:"*" usage:"comma separated origins allowed to call the service from a browser"`
+	ReadTimeout      time.Duration `config:"read_timeout" default:"5s" usage:"max time to read a request from the client"`
+	WriteTimeout     time.Duration `config:"write_timeout" default:"10s" usage:"max time to write a response to the client"`
+	IdleTimeout      time.Duration `config:"idle_timeout" default:"120s" usage:"max time for connections using TCP Keep-Alive"`
+	ShutdownTimeout  time.Duration `config:"shutdown_timeout" default:"30s" usage:"max time to wait for requests to complete when shutting down"`
+	LogLevel         string        `config:"log_level" default:"info" usage:"log level, trace, debug, info, warn or error"`
+	TraceOutput      string        `config:"trace_output" usage:"where to write trace spans, stdout or a file path, tracing is disabled when empty"`
+	JWKSFile         string        `config:"jwks_file" usage:"path to a JSON Web Key Set with the keys JWTs are verified with"`
+	JWTIssuer        string        `config:"jwt_issuer" usage:"required issuer of JWTs, not checked when empty"`
+	JWTAudience      string        `config:"jwt_audience" usage:"required audience of JWTs, not checked when empty"`
+	APIKeysFile      string        `config:"api_keys_file" usage:"path to a JSON file with the API keys which are accepted and their scopes"`
+	RateLimitReads   string        `config:"rate_limit_reads" default:"600/1m" usage:"images each client can download, in the format requests/duration, not limited when empty"`
+	RateLimitUploads string        `config:"rate_limit_uploads" default:"10/1m" usage:"images each client can upload, in the format requests/duration, not limited when empty"`
+	RateLimitAuth    string        `config:"rate_limit_auth" default:"30/1m" usage:"uploads each IP address can attempt before authentication, limiting the credentials it can try, in the format requests/duration, not limited when empty"`
+	Renditions       []string      `config:"renditions" default:"thumbnail=160x160,medium=640x640" usage:"comma separated renditions created for every upload in the format name=WIDTHxHEIGHT, stored as {id}/{name}.jpg next to the original"`
+	MaxImagePixels   int           `config:"max_image_pixels" default:"25000000" usage:"largest number of pixels in an uploaded image"`
+}
+
+// Validate checks the settings can be used to start the service
+func (c *Config) Validate() error {
+	if c.BindAddress == "" || c.BasePath == "" {
+		return fmt.Errorf("bind_address and base_path must be set")
+	}
+
+	if c.MaxFileSize <= 0 || c.MaxImagePixels <= 0 {
+		return fmt.Errorf("max_file_size and max_image_pixels must be greater than zero")
+	}
+
+	if _, err := images.ParseRenditions(c.Renditions); err != nil {
+		return err
+	}
+
+	if c.ReadTimeout <= 0 || c.WriteTimeout <= 0 || c.IdleTimeout <= 0 || c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("Timeouts must be greater than zero")
+	}
+
+	if hclog.LevelFromString(c.LogLevel) == hclog.NoLevel {
+		return fmt.Errorf("Unknown log level %s", c.LogLevel)
+	}
+
+	if _, _, _, err := c.rateLimits(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// rateLimits returns the limits of the requests to download and upload images,
+// and of the uploads each IP address can attempt before authentication
+func (c *Config) rateLimits() (reads ratelimit.Limit, uploads ratelimit.Limit, authn ratelimit.Limit, err error) {
+	reads, err = ratelimit.ParseLimit(c.RateLimitReads)
+	if err != nil {
+		return reads, uploads, authn, fmt.Errorf("rate_limit_reads: %w", err)
+	}
+
+	uploads, err = ratelimit.ParseLimit(c.RateLimitUploads)
+	if err != nil {
+		return reads, uploads, authn, fmt.Errorf("rate_limit_uploads: %w", err)
+	}
+
+	authn, err = ratelimit.ParseLimit(c.RateLimitAuth)
+	if err != nil {
+		return reads, uploads, authn, fmt.Errorf("rate_limit_auth: %w", err)
+	}
+
+	return reads, uploads, authn, nil
+}
@@ -2,6 +2,7 @@ package files
 
 import (
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -84,6 +85,23 @@ func (l *Local) Get(path string) (*os.File, error) {
 	return f, nil
 }
 
+// CheckWritable returns an error when files can not be written to the base
+// path, a temporary file is created and removed to check
+func (l *Local) CheckWritable() error {
+	f, err := ioutil.TempFile(l.basePath, ".healthcheck-")
+	if err != nil {
+		return xerrors.Errorf("Unable to write to storage: %w", err)
+	}
+	f.Close()
+
+	err = os.Remove(f.Name())
+	if err != nil {
+		return xerrors.Errorf("Unable to remove file from storage: %w", err)
+	}
+
+	return nil
+}
+
 // returns the absolute path
 func (l *Local) fullPath(path string) string {
 	// append the given path to the base path
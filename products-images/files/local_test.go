@@ -17,7 +17,7 @@ func setupLocal(t *testing.T) (*Local, string, func()) {
 		t.Fatal(err)
 	}
 
-	l, err := NewLocal(dir)
+	l, err := NewLocal(dir, 1024)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -66,3 +66,19 @@ func TestGetsContentsAndWritesToWriter(t *testing.T) {
 	d, err := ioutil.ReadAll(r)
 	assert.Equal(t, fileContents, string(d))
 }
+
+func TestCheckWritable(t *testing.T) {
+	l, dir, cleanup := setupLocal(t)
+	defer cleanup()
+
+	assert.NoError(t, l.CheckWritable())
+
+	// the check does not leave files behind
+	fs, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, fs)
+
+	l, err = NewLocal(filepath.Join(dir, "missing"), 1024)
+	assert.NoError(t, err)
+	assert.Error(t, l.CheckWritable())
+}
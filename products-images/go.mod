@@ -3,9 +3,35 @@ module github.com/JamieBShaw/golang-mux-rest-api/products-images
 go 1.14
 
 require (
-	github.com/gorilla/handlers v1.4.2 // indirect
-	github.com/gorilla/mux v1.7.4
+	github.com/JamieBShaw/golang-mux-rest-api/auth v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/config v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/health v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/metrics v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/ratelimit v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/requestlog v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/tracing v0.0.0
+	github.com/gorilla/handlers v1.4.2
+	github.com/gorilla/mux v1.8.0
 	github.com/hashicorp/go-hclog v0.14.1
-	github.com/joho/godotenv v1.3.0 // indirect
+	github.com/joho/godotenv v1.3.0
+	github.com/prometheus/client_golang v1.7.1
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux v0.11.0
+	go.opentelemetry.io/otel v0.11.0
+	golang.org/x/image v0.0.0-20200801110659-972c09e46d76
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1
 )
+
+replace github.com/JamieBShaw/golang-mux-rest-api/auth => ../auth
+
+replace github.com/JamieBShaw/golang-mux-rest-api/config => ../config
+
+replace github.com/JamieBShaw/golang-mux-rest-api/health => ../health
+
+replace github.com/JamieBShaw/golang-mux-rest-api/metrics => ../metrics
+
+replace github.com/JamieBShaw/golang-mux-rest-api/ratelimit => ../ratelimit
+
+replace github.com/JamieBShaw/golang-mux-rest-api/requestlog => ../requestlog
+
+replace github.com/JamieBShaw/golang-mux-rest-api/tracing => ../tracing
@@ -1,26 +1,46 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"path/filepath"
 	"strconv"
 
 	"github.com/JamieBShaw/golang-mux-rest-api/products-images/files"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-images/images"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
 
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
 )
 
+// tracer records the spans for the storage operations
+var tracer = global.Tracer("github.com/JamieBShaw/golang-mux-rest-api/products-images/handlers")
+
 // Files is a handler for reading and writing files
 type Files struct {
-	log   hclog.Logger
-	store files.Storage
+	log    hclog.Logger
+	store  files.Storage
+	images *images.Processor
+}
+
+// NewFiles creates a new File handler, uploads are validated and their
+// renditions created by the processor
+func NewFiles(s files.Storage, p *images.Processor, l hclog.Logger) *Files {
+	return &Files{store: s, images: p, log: l}
 }
 
-// NewFiles creates a new File handler
-func NewFiles(s files.Storage, l hclog.Logger) *Files {
-	return &Files{store: s, log: l}
+// logger returns the logger for the request the context belongs to, which
+// logs the request ID
+func (f *Files) logger(ctx context.Context) hclog.Logger {
+	return requestlog.Logger(ctx, f.log)
 }
 
 // UploadREST implements the http.Handler interface
@@ -29,16 +49,17 @@ func (f *Files) UploadREST(rw http.ResponseWriter, r *http.Request) {
 	id := vars["id"]
 	fn := vars["filename"]
 
-	f.log.Info("Handle POST", "id", id, "filename", fn)
+	f.logger(r.Context()).Info("Handle POST", "id", id, "filename", fn)
 
 	if id == "" || fn == "" {
-		f.invalidURI(r.URL.String(), rw)
+		f.invalidURI(rw, r)
+		return
 	}
 
 	// no need to check for invalid id or filename as the mux router will not send requests
 	// here unless they have the correct parameters
 
-	f.saveFile(id, fn, rw, r.Body)
+	f.saveFile(r.Context(), id, fn, rw, r.Body)
 }
 
 // UploadMultipart: Grabbing data from a multi input form
@@ -47,44 +68,117 @@ func (f *Files) UploadMultipart(rw http.ResponseWriter, r *http.Request) {
 	err := r.ParseMultipartForm(128 * 1024)
 
 	if err != nil {
-		f.log.Error("BAD REQUEST", err)
+		f.logger(r.Context()).Error("Unable to parse multipart form", "error", err)
+		uploadFailures.WithLabelValues("invalid_request").Inc()
 		http.Error(rw, "Expected multipart form data", http.StatusBadRequest)
 		return
 	}
 
 	id, idErr := strconv.Atoi(r.FormValue("id")) // Grabbing the form html element with name id
-	f.log.Info("Process form for id", "id", id)
+	f.logger(r.Context()).Info("Process form for id", "id", id)
 
 	if idErr != nil {
-		f.log.Error("BAD REQUEST", err)
+		f.logger(r.Context()).Error("Invalid product id in form", "id", r.FormValue("id"), "error", idErr)
+		uploadFailures.WithLabelValues("invalid_request").Inc()
 		http.Error(rw, "Expected integer id", http.StatusBadRequest)
 		return
 	}
 
 	ff, mh, err := r.FormFile("file") // Grabbing the form html element with name file (image upload)
 	if err != nil {
-		f.log.Error("BAD REQUEST", err)
+		f.logger(r.Context()).Error("Unable to read file from form", "error", err)
+		uploadFailures.WithLabelValues("invalid_request").Inc()
 		http.Error(rw, "Expected file", http.StatusBadRequest)
 		return
 	}
 
-	f.saveFile(r.FormValue("id"), mh.Filename, rw, ff)
+	f.saveFile(r.Context(), r.FormValue("id"), mh.Filename, rw, ff)
 
 }
 
-func (f *Files) invalidURI(uri string, rw http.ResponseWriter) {
-	f.log.Error("Invalid path", "path", uri)
+func (f *Files) invalidURI(rw http.ResponseWriter, r *http.Request) {
+	f.logger(r.Context()).Error("Invalid path", "path", r.URL.String())
 	http.Error(rw, "Invalid file path should be in the format: /[id]/[filepath]", http.StatusBadRequest)
 }
 
-// saveFile saves the contents of the request to a file
-func (f *Files) saveFile(id, path string, rw http.ResponseWriter, r io.ReadCloser) {
-	f.log.Info("Save file for product", "id", id, "path", path)
+// saveFile validates the image in the request and saves it with its
+// renditions, the renditions are saved in the same directory as the image
+func (f *Files) saveFile(ctx context.Context, id, path string, rw http.ResponseWriter, r io.ReadCloser) {
+	f.logger(ctx).Info("Save file for product", "id", id, "path", path)
+
+	if f.images.IsRendition(path) {
+		f.logger(ctx).Error("File name is used by a rendition", "path", path)
+		uploadFailures.WithLabelValues("invalid_request").Inc()
+		http.Error(rw, "File name is reserved for a rendition", http.StatusBadRequest)
+		return
+	}
+
+	cr := &countingReader{r: r}
+
+	pctx, span := tracer.Start(ctx, "Image.Process", trace.WithAttributes(label.String("file.path", path)))
+	img, err := f.images.Process(cr)
+	span.SetAttributes(label.Int("file.bytes", cr.n))
+	span.RecordError(pctx, err, trace.WithErrorStatus(codes.Unknown))
+	span.End()
+
+	if err != nil {
+		f.logger(ctx).Error("Unable to process image", "path", path, "error", err)
+		f.invalidImage(rw, err)
+		return
+	}
+
+	if !images.ValidExtension(img.Format, path) {
+		f.logger(ctx).Error("File extension does not match the image", "path", path, "format", img.Format)
+		uploadFailures.WithLabelValues("invalid_image").Inc()
+		http.Error(rw, "File extension does not match the "+img.Format+" image", http.StatusBadRequest)
+		return
+	}
+
+	// the renditions are saved before the original so clients do not find an
+	// original without its renditions
+	for name, b := range img.Renditions {
+		if !f.save(ctx, filepath.Join(id, name+images.RenditionExt), b, rw) {
+			return
+		}
+	}
+
+	if !f.save(ctx, filepath.Join(id, path), img.Original, rw) {
+		return
+	}
+
+	uploadBytes.Add(float64(cr.n))
+}
+
+// invalidImage writes the error response for an upload which could not be
+// processed
+func (f *Files) invalidImage(rw http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, images.ErrTooLarge):
+		uploadFailures.WithLabelValues("too_large").Inc()
+		http.Error(rw, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, images.ErrInvalidImage), errors.Is(err, images.ErrTooManyPixels):
+		uploadFailures.WithLabelValues("invalid_image").Inc()
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+	default:
+		uploadFailures.WithLabelValues("invalid_request").Inc()
+		http.Error(rw, "Unable to read file", http.StatusBadRequest)
+	}
+}
+
+// save writes the file to the storage, false is returned and the error
+// response written when it can not be saved
+func (f *Files) save(ctx context.Context, fp string, b []byte, rw http.ResponseWriter) bool {
+	ctx, span := tracer.Start(ctx, "Storage.Save", trace.WithAttributes(label.String("file.path", fp), label.Int("file.bytes", len(b))))
+	err := f.store.Save(fp, bytes.NewReader(b))
+	span.RecordError(ctx, err, trace.WithErrorStatus(codes.Unknown))
+	span.End()
 
-	fp := filepath.Join(id, path)
-	err := f.store.Save(fp, r)
 	if err != nil {
-		f.log.Error("Unable to save file", "error", err)
+		f.logger(ctx).Error("Unable to save file", "path", fp, "error", err)
+		uploadFailures.WithLabelValues("storage").Inc()
 		http.Error(rw, "Unable to save file", http.StatusInternalServerError)
+		return false
 	}
+
+	return true
 }
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-images/images"
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStorage reads the whole file, failing when err is set
+type fakeStorage struct {
+	err   error
+	saved []string
+}
+
+func (f *fakeStorage) Save(path string, r io.Reader) error {
+	if _, err := ioutil.ReadAll(r); err != nil {
+		return err
+	}
+	f.saved = append(f.saved, path)
+
+	return f.err
+}
+
+// testProcessor creates a thumbnail of every upload
+func testProcessor() *images.Processor {
+	return images.NewProcessor([]images.Rendition{{Name: "thumbnail", Width: 16, Height: 16}}, 1<<20, 1<<20)
+}
+
+// testPNG returns a PNG image
+func testPNG(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 32, 32))))
+
+	return buf.Bytes()
+}
+
+func saveFile(fh *Files, path string, b []byte) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	fh.saveFile(context.Background(), "1", path, rw, ioutil.NopCloser(bytes.NewReader(b)))
+
+	return rw
+}
+
+func TestSaveFileRecordsUploadMetrics(t *testing.T) {
+	img := testPNG(t)
+	bytesBefore := testutil.ToFloat64(uploadBytes)
+	failuresBefore := testutil.ToFloat64(uploadFailures.WithLabelValues("storage"))
+
+	fh := NewFiles(&fakeStorage{}, testProcessor(), hclog.NewNullLogger())
+	rw := saveFile(fh, "test.png", img)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, bytesBefore+float64(len(img)), testutil.ToFloat64(uploadBytes))
+
+	fh = NewFiles(&fakeStorage{err: fmt.Errorf("disk full")}, testProcessor(), hclog.NewNullLogger())
+	rw = saveFile(fh, "test.png", img)
+
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.Equal(t, bytesBefore+float64(len(img)), testutil.ToFloat64(uploadBytes))
+	assert.Equal(t, failuresBefore+1, testutil.ToFloat64(uploadFailures.WithLabelValues("storage")))
+}
+
+func TestSaveFileStoresRenditions(t *testing.T) {
+	fs := &fakeStorage{}
+	fh := NewFiles(fs, testProcessor(), hclog.NewNullLogger())
+
+	rw := saveFile(fh, "test.png", testPNG(t))
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, []string{filepath.Join("1", "thumbnail.jpg"), filepath.Join("1", "test.png")}, fs.saved)
+}
+
+func TestSaveFileRejectsInvalidUploads(t *testing.T) {
+	invalidBefore := testutil.ToFloat64(uploadFailures.WithLabelValues("invalid_image"))
+
+	tests := []struct {
+		name string
+		path string
+		body []byte
+		code int
+	}{
+		{"not an image", "test.png", []byte("Hello World"), http.StatusBadRequest},
+		{"extension does not match", "test.jpg", testPNG(t), http.StatusBadRequest},
+		{"name of a rendition", "thumbnail.jpg", testPNG(t), http.StatusBadRequest},
+		{"too large", "test.png", make([]byte, 1<<20+1), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := &fakeStorage{}
+			rw := saveFile(NewFiles(fs, testProcessor(), hclog.NewNullLogger()), tc.path, tc.body)
+
+			assert.Equal(t, tc.code, rw.Code)
+			assert.Empty(t, fs.saved)
+		})
+	}
+
+	assert.Equal(t, invalidBefore+2, testutil.ToFloat64(uploadFailures.WithLabelValues("invalid_image")))
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"io"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metrics for the uploads, the HTTP request metrics are recorded by the
+// shared metrics middleware
+var (
+	uploadBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "images_upload_bytes_total",
+		Help: "Bytes of images successfully uploaded",
+	})
+
+	uploadFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "images_upload_failures_total",
+		Help: "Uploads which failed by reason, invalid_request, invalid_image, too_large or storage",
+	}, []string{"reason"})
+)
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
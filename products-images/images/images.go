@@ -0,0 +1,268 @@
+// Package images validates uploaded images and creates the renditions of
+// them which are served to clients.
+//
+// Uploads must be PNG, JPEG or GIF images. The original is decoded and encoded
+// again in its own format, which removes EXIF and any other metadata, after
+// being rotated upright using its EXIF orientation. Each rendition is scaled to
+// fit its size and encoded as a JPEG so clients can find it by name
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// RenditionExt is the file extension of the renditions
+const RenditionExt = ".jpg"
+
+// quality of the JPEG encoded originals and renditions
+const (
+	originalQuality  = 90
+	renditionQuality = 85
+)
+
+// ErrTooLarge is an error raised when an upload is larger than the maximum
+// file size
+var ErrTooLarge = fmt.Errorf("Image is larger than the maximum file size")
+
+// ErrInvalidImage is an error raised when an upload is not a PNG, JPEG or GIF
+// image
+var ErrInvalidImage = fmt.Errorf("Invalid image, expected a PNG, JPEG or GIF")
+
+// ErrTooManyPixels is an error raised when the dimensions of an image are
+// larger than the maximum number of pixels, decoding it would use too much
+// memory
+var ErrTooManyPixels = fmt.Errorf("Image has too many pixels")
+
+// ErrInvalidRendition is an error raised when a rendition can not be parsed
+var ErrInvalidRendition = fmt.Errorf("Invalid rendition, expected the format name=WIDTHxHEIGHT e.g. thumbnail=160x160")
+
+// renditionName is the format of rendition names, they are used as file names
+// so must match the file names served by the service
+var renditionName = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// Rendition is a scaled copy of an image
+type Rendition struct {
+	// Name of the rendition, it is stored as Name + RenditionExt
+	Name string
+
+	// Width and Height of the box the image is scaled to fit, keeping its
+	// aspect ratio. Images smaller than the box are not enlarged
+	Width  int
+	Height int
+}
+
+// ParseRenditions parses renditions in the format name=WIDTHxHEIGHT
+func ParseRenditions(specs []string) ([]Rendition, error) {
+	rs := []Rendition{}
+	seen := map[string]bool{}
+
+	for _, s := range specs {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 || !renditionName.MatchString(parts[0]) || seen[parts[0]] {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRendition, s)
+		}
+
+		size := strings.SplitN(parts[1], "x", 2)
+		if len(size) != 2 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRendition, s)
+		}
+
+		w, werr := strconv.Atoi(size[0])
+		h, herr := strconv.Atoi(size[1])
+		if werr != nil || herr != nil || w <= 0 || h <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRendition, s)
+		}
+
+		seen[parts[0]] = true
+		rs = append(rs, Rendition{Name: parts[0], Width: w, Height: h})
+	}
+
+	return rs, nil
+}
+
+// Processed is an upload which has been validated and the renditions created
+type Processed struct {
+	// Format of the original, png, jpeg or gif
+	Format string
+
+	// Original is the re-encoded original without metadata
+	Original []byte
+
+	// Renditions are the encoded renditions by name
+	Renditions map[string][]byte
+}
+
+// Processor validates uploads and creates their renditions
+type Processor struct {
+	renditions []Rendition
+	maxBytes   int
+	maxPixels  int
+}
+
+// NewProcessor returns a Processor creating the renditions of images up to
+// maxBytes in size with at most maxPixels pixels
+func NewProcessor(renditions []Rendition, maxBytes, maxPixels int) *Processor {
+	return &Processor{renditions: renditions, maxBytes: maxBytes, maxPixels: maxPixels}
+}
+
+// Renditions returns the renditions which are created
+func (p *Processor) Renditions() []Rendition {
+	return p.renditions
+}
+
+// IsRendition returns true when the file name is used by a rendition, an
+// upload with the name would be overwritten
+func (p *Processor) IsRendition(filename string) bool {
+	for _, rd := range p.renditions {
+		if filename == rd.Name+RenditionExt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidExtension returns true when the extension of the file name is used for
+// images in the format
+func ValidExtension(format, filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".png":
+		return format == "png"
+	case ".jpg", ".jpeg":
+		return format == "jpeg"
+	case ".gif":
+		return format == "gif"
+	}
+
+	return false
+}
+
+// Process reads an image from r, validates it and creates the renditions
+func (p *Processor) Process(r io.Reader) (*Processed, error) {
+	// read one byte more than allowed to know when the upload is too large
+	b, err := ioutil.ReadAll(io.LimitReader(r, int64(p.maxBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > p.maxBytes {
+		return nil, ErrTooLarge
+	}
+
+	// check the dimensions before decoding the pixels
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+	}
+	if format != "png" && format != "jpeg" && format != "gif" {
+		return nil, fmt.Errorf("%w: got %s", ErrInvalidImage, format)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > p.maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+	}
+
+	if format == "jpeg" {
+		img = orient(img, orientation(b))
+	}
+
+	pr := &Processed{Format: format, Renditions: map[string][]byte{}}
+	pr.Original, err = encodeOriginal(b, img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rd := range p.renditions {
+		pr.Renditions[rd.Name], err = encodeRendition(img, rd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pr, nil
+}
+
+// encodeOriginal encodes the image in its original format, every frame of an
+// animated GIF is kept
+func encodeOriginal(b []byte, img image.Image, format string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(buf, img)
+	case "jpeg":
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: originalQuality})
+	case "gif":
+		var g *gif.GIF
+		g, err = gif.DecodeAll(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+		}
+		err = gif.EncodeAll(buf, g)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode image: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeRendition scales the image to fit the rendition and encodes it as a
+// JPEG, transparent pixels are drawn on a white background. Only the first
+// frame of an animated GIF is used
+func encodeRendition(img image.Image, rd Rendition) ([]byte, error) {
+	w, h := fit(img.Bounds().Dx(), img.Bounds().Dy(), rd.Width, rd.Height)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Over, nil)
+
+	buf := &bytes.Buffer{}
+	err := jpeg.Encode(buf, dst, &jpeg.Options{Quality: renditionQuality})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode rendition %s: %w", rd.Name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fit returns the size of an image scaled to fit the box keeping its aspect
+// ratio, images which already fit are not scaled
+func fit(w, h, boxW, boxH int) (int, int) {
+	if w <= boxW && h <= boxH {
+		return w, h
+	}
+
+	// scale by the dimension which is furthest outside of the box
+	if w*boxH > h*boxW {
+		return boxW, maxInt(1, (h*boxW+w/2)/w)
+	}
+
+	return maxInt(1, (w*boxH+h/2)/h), boxH
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,184 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRenditions = []Rendition{{Name: "thumbnail", Width: 16, Height: 16}, {Name: "medium", Width: 64, Height: 64}}
+
+// testImage returns a blue image with a red top left corner, a quarter of the
+// width and height, so its orientation can be checked
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	cw, ch := maxInt(1, w/4), maxInt(1, h/4)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < cw && y < ch {
+				img.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				img.Set(x, y, color.RGBA{0, 0, 255, 255})
+			}
+		}
+	}
+
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, img))
+
+	return buf.Bytes()
+}
+
+// encodeJPEG encodes the image with an EXIF segment holding the orientation
+func encodeJPEG(t *testing.T, img image.Image, orientation uint16) []byte {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, jpeg.Encode(buf, img, &jpeg.Options{Quality: 100}))
+	b := buf.Bytes()
+
+	// a big endian TIFF header and an IFD with the orientation tag
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = append(tiff, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00)
+	binary.BigEndian.PutUint16(tiff[len(tiff)-4:], orientation)
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00)
+
+	seg := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0x00, 0x00}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(seg)+2))
+	app1 = append(app1, seg...)
+
+	return append(append(append([]byte{}, b[:2]...), app1...), b[2:]...)
+}
+
+func decode(t *testing.T, b []byte) image.Image {
+	img, _, err := image.Decode(bytes.NewReader(b))
+	assert.NoError(t, err)
+
+	return img
+}
+
+func TestParseRenditions(t *testing.T) {
+	rs, err := ParseRenditions([]string{"thumbnail=160x160", "medium=640x480"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Rendition{{"thumbnail", 160, 160}, {"medium", 640, 480}}, rs)
+
+	invalid := [][]string{
+		{"thumbnail"},
+		{"thumbnail=160"},
+		{"thumbnail=0x160"},
+		{"thumb_nail=160x160"},
+		{"thumbnail=160x160", "thumbnail=320x320"},
+	}
+	for _, specs := range invalid {
+		_, err := ParseRenditions(specs)
+		assert.True(t, errors.Is(err, ErrInvalidRendition), specs)
+	}
+}
+
+func TestProcessCreatesRenditions(t *testing.T) {
+	p := NewProcessor(testRenditions, 1<<20, 1<<20)
+
+	pr, err := p.Process(bytes.NewReader(encodePNG(t, testImage(200, 100))))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", pr.Format)
+	assert.Equal(t, image.Rect(0, 0, 200, 100), decode(t, pr.Original).Bounds())
+
+	// renditions keep the aspect ratio of the original
+	assert.Equal(t, image.Rect(0, 0, 16, 8), decode(t, pr.Renditions["thumbnail"]).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 64, 32), decode(t, pr.Renditions["medium"]).Bounds())
+
+	_, format, _ := image.DecodeConfig(bytes.NewReader(pr.Renditions["thumbnail"]))
+	assert.Equal(t, "jpeg", format)
+}
+
+func TestProcessDoesNotEnlargeSmallImages(t *testing.T) {
+	p := NewProcessor(testRenditions, 1<<20, 1<<20)
+
+	pr, err := p.Process(bytes.NewReader(encodePNG(t, testImage(10, 40))))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 16), decode(t, pr.Renditions["thumbnail"]).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 10, 40), decode(t, pr.Renditions["medium"]).Bounds())
+}
+
+func TestProcessRejectsInvalidImages(t *testing.T) {
+	png := encodePNG(t, testImage(100, 100))
+
+	_, err := NewProcessor(testRenditions, 1<<20, 1<<20).Process(bytes.NewBufferString("Hello World"))
+	assert.True(t, errors.Is(err, ErrInvalidImage))
+
+	_, err = NewProcessor(testRenditions, len(png)-1, 1<<20).Process(bytes.NewReader(png))
+	assert.True(t, errors.Is(err, ErrTooLarge))
+
+	_, err = NewProcessor(testRenditions, 1<<20, 100*100-1).Process(bytes.NewReader(png))
+	assert.True(t, errors.Is(err, ErrTooManyPixels))
+}
+
+func TestProcessStripsEXIFAndRotatesUpright(t *testing.T) {
+	b := encodeJPEG(t, testImage(40, 20), 6)
+	assert.Equal(t, 6, orientation(b))
+
+	pr, err := NewProcessor(testRenditions, 1<<20, 1<<20).Process(bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", pr.Format)
+	assert.False(t, bytes.Contains(pr.Original, []byte("Exif")))
+	assert.Equal(t, 1, orientation(pr.Original))
+
+	// rotated 90 degrees clockwise the top left corner is top right
+	img := decode(t, pr.Original)
+	assert.Equal(t, image.Rect(0, 0, 20, 40), img.Bounds())
+	r, _, bl, _ := img.At(17, 4).RGBA()
+	assert.True(t, r > bl, "expected red in the top right corner")
+	r, _, bl, _ = img.At(2, 4).RGBA()
+	assert.True(t, r < bl, "expected blue in the top left corner")
+}
+
+func TestOrient(t *testing.T) {
+	// the position of the top left pixel after each orientation is applied
+	corners := map[int]image.Point{
+		1: {0, 0}, 2: {2, 0}, 3: {2, 1}, 4: {0, 1},
+		5: {0, 0}, 6: {1, 0}, 7: {1, 2}, 8: {0, 2},
+	}
+
+	for o, p := range corners {
+		img := orient(testImage(3, 2), o)
+		assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(p.X, p.Y)), "orientation %d", o)
+	}
+}
+
+func TestProcessKeepsGIFAnimation(t *testing.T) {
+	pal := color.Palette{color.White, color.Black}
+	g := &gif.GIF{LoopCount: 0}
+	for i := 0; i < 3; i++ {
+		g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, 32, 32), pal))
+		g.Delay = append(g.Delay, 10)
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, gif.EncodeAll(buf, g))
+
+	pr, err := NewProcessor(testRenditions, 1<<20, 1<<20).Process(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "gif", pr.Format)
+
+	out, err := gif.DecodeAll(bytes.NewReader(pr.Original))
+	assert.NoError(t, err)
+	assert.Len(t, out.Image, 3)
+}
+
+func TestValidExtension(t *testing.T) {
+	assert.True(t, ValidExtension("png", "coffee.png"))
+	assert.True(t, ValidExtension("jpeg", "coffee.JPG"))
+	assert.True(t, ValidExtension("jpeg", "coffee.jpeg"))
+	assert.False(t, ValidExtension("png", "coffee.jpg"))
+	assert.False(t, ValidExtension("gif", "coffee"))
+}
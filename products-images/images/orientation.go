@@ -0,0 +1,126 @@
+package images
+
+import (
+	"encoding/binary"
+	"image"
+)
+
+// the EXIF tag holding the orientation of the camera when a photo was taken
+const tagOrientation = 0x0112
+
+// orientation returns the EXIF orientation of a JPEG, 1 (upright) is returned
+// when the image has no orientation
+func orientation(b []byte) int {
+	if len(b) < 4 || b[0] != 0xFF || b[1] != 0xD8 {
+		return 1
+	}
+
+	// walk the segments until the APP1 segment with the EXIF data, which comes
+	// before the image data
+	for i := 2; i+4 <= len(b); {
+		if b[i] != 0xFF {
+			return 1
+		}
+
+		marker := b[i+1]
+		if marker == 0xD9 || marker == 0xDA {
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(b[i+2:]))
+		if size < 2 || i+2+size > len(b) {
+			return 1
+		}
+
+		seg := b[i+4 : i+2+size]
+		if marker == 0xE1 && len(seg) > 6 && string(seg[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(seg[6:])
+		}
+
+		i += 2 + size
+	}
+
+	return 1
+}
+
+// tiffOrientation returns the orientation from the first IFD of the TIFF
+// structure holding the EXIF data
+func tiffOrientation(t []byte) int {
+	if len(t) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(t[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(t[4:]))
+	if ifd < 8 || ifd+2 > len(t) {
+		return 1
+	}
+
+	n := int(order.Uint16(t[ifd:]))
+	for e := ifd + 2; e+12 <= len(t) && n > 0; e, n = e+12, n-1 {
+		if order.Uint16(t[e:]) == tagOrientation {
+			o := int(order.Uint16(t[e+8:]))
+			if o < 1 || o > 8 {
+				return 1
+			}
+			return o
+		}
+	}
+
+	return 1
+}
+
+// orient returns the image transformed so it is upright for the EXIF
+// orientation, orientations 5 to 8 swap the width and height
+func orient(img image.Image, o int) image.Image {
+	if o <= 1 || o > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+
+	// src returns the pixel of the original shown at x, y of the result
+	src := func(x, y int) (int, int) {
+		switch o {
+		case 2:
+			return w - 1 - x, y
+		case 3:
+			return w - 1 - x, h - 1 - y
+		case 4:
+			return x, h - 1 - y
+		case 5:
+			return y, x
+		case 6:
+			return y, h - 1 - x
+		case 7:
+			return w - 1 - y, h - 1 - x
+		default:
+			return w - 1 - y, x
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := src(x, y)
+			dst.Set(x, y, img.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+
+	return dst
+}
@@ -2,89 +2,169 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
-	"time"
 
+	"github.com/JamieBShaw/golang-mux-rest-api/auth"
+	"github.com/JamieBShaw/golang-mux-rest-api/config"
+	"github.com/JamieBShaw/golang-mux-rest-api/health"
+	"github.com/JamieBShaw/golang-mux-rest-api/metrics"
 	"github.com/JamieBShaw/golang-mux-rest-api/products-images/files"
 	"github.com/JamieBShaw/golang-mux-rest-api/products-images/handlers"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-images/images"
+	"github.com/JamieBShaw/golang-mux-rest-api/ratelimit"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"github.com/JamieBShaw/golang-mux-rest-api/tracing"
 
 	gohandlers "github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-hclog"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	muxtrace "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux"
 )
 
+// serviceName identifies the logs, flags and spans of this service
+const serviceName = "products-images"
+
 func main() {
 
+	// the .env file is optional, its variables do not override the environment
 	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+	if err != nil && !os.IsNotExist(err) {
+		hclog.Default().Error("Unable to load .env file", "error", err)
+		os.Exit(2)
 	}
 
-	bindAddress := os.Getenv("BIND_ADDRESS")
-	logLevel := os.Getenv("LOG_LEVEL")
-	basePath := os.Getenv("BASE_PATH")
+	cfg := &Config{}
+	err = config.Load(serviceName, "", cfg, os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		hclog.Default().Error("Unable to load configuration", "error", err)
+		os.Exit(2)
+	}
 
 	l := hclog.New(
 		&hclog.LoggerOptions{
-			Name:  "product-images",
-			Level: hclog.LevelFromString(logLevel),
+			Name:  serviceName,
+			Level: hclog.LevelFromString(cfg.LogLevel),
 		},
 	)
+	l.Info("Loaded configuration", config.Values(cfg)...)
 
 	sl := l.StandardLogger(&hclog.StandardLoggerOptions{InferLevels: true})
 
-	stor, err := files.NewLocal(basePath, 1024*1000*5)
+	closeTracing, err := tracing.Setup(serviceName, cfg.TraceOutput)
+	if err != nil {
+		l.Error("Unable to set up tracing", "output", cfg.TraceOutput, "error", err)
+		os.Exit(1)
+	}
+	defer closeTracing()
+
+	stor, err := files.NewLocal(cfg.BasePath, cfg.MaxFileSize)
 	if err != nil {
 		l.Error("Unable to create storage", "error", err)
 		os.Exit(1)
 	}
 
+	// uploads are validated and resized before they are stored
+	renditions, err := images.ParseRenditions(cfg.Renditions)
+	if err != nil {
+		l.Error("Invalid renditions", "error", err)
+		os.Exit(1)
+	}
+	ip := images.NewProcessor(renditions, cfg.MaxFileSize, cfg.MaxImagePixels)
+
 	// create the handlers
-	fh := handlers.NewFiles(stor, l)
+	fh := handlers.NewFiles(stor, ip, l)
+	hh := health.NewHandler(l, map[string]health.Check{"storage": stor.CheckWritable})
 	mw := handlers.GziHandler{}
 
+	// uploads require the images:write scope
+	ac, err := auth.LoadConfig(cfg.JWKSFile, cfg.APIKeysFile)
+	if err != nil {
+		l.Error("Unable to load authentication keys", "error", err)
+		os.Exit(1)
+	}
+	if ac.Keys == nil && len(ac.APIKeys) == 0 {
+		l.Warn("No JWKS or API keys configured, uploads will be rejected")
+	}
+	ac.Issuer, ac.Audience = cfg.JWTIssuer, cfg.JWTAudience
+	authn := auth.NewAuthenticator(ac, l)
+
+	// limit the requests of each client, uploads are limited once the caller
+	// is authenticated so they are limited by API key or token subject. They
+	// are also limited by IP address before authentication so rejected
+	// credentials can not be tried without limit
+	reads, uploads, authLimit, err := cfg.rateLimits()
+	if err != nil {
+		l.Error("Invalid rate limits", "error", err)
+		os.Exit(1)
+	}
+	store := ratelimit.NewMemoryStore()
+	limiter := ratelimit.NewLimiter(store, ratelimit.ClientKey, l)
+	ipLimiter := ratelimit.NewLimiter(store, ratelimit.IPKey, l)
+
 	// create a new serve mux and register the handlers
 	sm := mux.NewRouter()
 
+	// identify, log, trace and record the requests to every route
+	sm.Use(requestlog.Middleware(l))
+	sm.Use(muxtrace.Middleware(serviceName))
+	sm.Use(metrics.Middleware)
+
 	// filename regex: {filename:[a-zA-Z]+\\.[a-z]{3}}
 	// problem with FileServer is that it is dumb
 	ph := sm.Methods(http.MethodPost).Subrouter()
 	ph.HandleFunc("/images/{id:[0-9]+}/{filename:[a-zA-Z]+\\.[a-z]{3}}", fh.UploadREST)
 	ph.HandleFunc("/", fh.UploadMultipart) //MultiPart
+	ph.Use(ipLimiter.Middleware("auth", authLimit))
+	ph.Use(authn.Require("images:write"))
+	ph.Use(limiter.Middleware("uploads", uploads))
 
 	// get files
 	gh := sm.Methods(http.MethodGet).Subrouter()
 	gh.Handle(
 		"/images/{id:[0-9]+}/{filename:[a-zA-Z]+\\.[a-z]{3}}",
-		http.StripPrefix("/images/", http.FileServer(http.Dir(basePath))),
+		http.StripPrefix("/images/", http.FileServer(http.Dir(cfg.BasePath))),
 	)
 
+	gh.Use(limiter.Middleware("reads", reads))
 	gh.Use(mw.GzipMiddleware)
 
+	// health checks
+	hr := sm.Methods(http.MethodGet).Subrouter()
+	hr.HandleFunc("/healthz", hh.Live)
+	hr.HandleFunc("/readyz", hh.Ready)
+	hr.Handle("/metrics", promhttp.Handler())
+
 	// Enable CORS
 
-	ch := gohandlers.CORS(gohandlers.AllowedOrigins([]string{"*"}))
+	ch := gohandlers.CORS(
+		gohandlers.AllowedOrigins(cfg.CORSOrigins),
+		gohandlers.AllowedHeaders([]string{"Content-Type", "Authorization", auth.HeaderAPIKey}),
+	)
 
 	// create a new server
 	s := http.Server{
-		Addr:         bindAddress,       // configure the bind address
-		Handler:      ch(sm),            // set the default handler
-		ErrorLog:     sl,                // the logger for the server
-		ReadTimeout:  5 * time.Second,   // max time to read request from the client
-		WriteTimeout: 10 * time.Second,  // max time to write response to the client
-		IdleTimeout:  120 * time.Second, // max time for connections using TCP Keep-Alive
+		Addr:         cfg.BindAddress,  // configure the bind address
+		Handler:      ch(sm),           // set the default handler
+		ErrorLog:     sl,               // the logger for the server
+		ReadTimeout:  cfg.ReadTimeout,  // max time to read request from the client
+		WriteTimeout: cfg.WriteTimeout, // max time to write response to the client
+		IdleTimeout:  cfg.IdleTimeout,  // max time for connections using TCP Keep-Alive
 	}
 
 	// start the server
 	go func() {
-		l.Info("Starting server", "bind_address", bindAddress)
+		l.Info("Starting server", "bind_address", cfg.BindAddress)
 
 		err := s.ListenAndServe()
-		if err != nil {
+		if err != nil && err != http.ErrServerClosed {
 			l.Error("Unable to start server", "error", err)
 			os.Exit(1)
 		}
@@ -99,8 +179,9 @@ func main() {
 	sig := <-c
 	l.Info("Shutting down server with", "signal", sig)
 
-	// gracefully shutdown the server, waiting max 30 seconds for current operations to complete
-	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+	// gracefully shutdown the server, waiting for current operations to complete
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
 	s.Shutdown(ctx)
 
 }
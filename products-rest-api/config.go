@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/security"
+	"github.com/JamieBShaw/golang-mux-rest-api/ratelimit"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Config is the configuration of the products API, see config.Load for how it
+// is set
+type Config struct {
+	ListenAddr      string        `config:"listen_addr" default:":9090" usage:"address the API listens on"`
+	ServerAddr      string        `config:"server_addr" default:"localhost:9092" usage:"grpc server in format host:port"`
+	CORSOrigins     []string      `config:"cors_origins" default:"http://localhost:3000" usage:"comma separated origins allowed to call the API from a browser"`
+	ReadTimeout     time.Duration `config:"read_timeout" default:"5s" usage:"max time to read a request from the client"`
+	WriteTimeout    time.Duration `config:"write_timeout" default:"10s" usage:"max time to write a response to the client"`
+	IdleTimeout     time.Duration `config:"idle_timeout" default:"120s" usage:"max time for connections using TCP Keep-Alive"`
+	ShutdownTimeout time.Duration `config:"shutdown_timeout" default:"30s" usage:"max time to wait for requests to complete when shutting down"`
+	LogLevel        string        `config:"log_level" default:"info" usage:"log level, trace, debug, info, warn or error"`
+	Store           string        `config:"store" default:"memory" usage:"product storage backend, memory or sqlite"`
+	DBPath          string        `config:"db_path" default:"products.db" usage:"path to the SQLite database when using the sqlite store"`
+	TraceOutput     string        `config:"trace_output" usage:"where to write trace spans, stdout or a file path, tracing is disabled when empty"`
+	JWKSFile        string        `config:"jwks_file" usage:"path to a JSON Web Key Set with the keys JWTs are verified with"`
+	JWTIssuer       string        `config:"jwt_issuer" usage:"required issuer of JWTs, not checked when empty"`
+	JWTAudience     string        `config:"jwt_audience" usage:"required audience of JWTs, not checked when empty"`
+	APIKeysFile     string        `config:"api_keys_file" usage:"path to a JSON file with the API keys which are accepted and their scopes"`
+	CurrencyTLS     bool          `config:"currency_tls" default:"false" usage:"connect to the currency server over TLS"`
+	CurrencyCAFile  string        `config:"currency_ca_file" usage:"PEM CA certificates the currency server is verified with, the system CAs are used when empty"`
+	CurrencyCert    string        `config:"currency_cert_file" usage:"PEM client certificate presented to the currency server for mutual TLS"`
+	CurrencyKey     string        `config:"currency_key_file" usage:"PEM private key of currency_cert_file"`
+	CurrencyName    string        `config:"currency_server_name" usage:"name the currency server certificate is verified for, the host of server_addr when empty"`
+	CurrencyToken   string        `config:"currency_token" secret:"true" usage:"bearer token sent with every call to the currency server, requires currency_tls"`
+	RateLimitReads  string        `config:"rate_limit_reads" default:"300/1m" usage:"requests each client can make to read products and currencies, in the format requests/duration, not limited when empty"`
+	RateLimitWrites string        `config:"rate_limit_writes" default:"30/1m" usage:"changes to products each client can make, in the format requests/duration, not limited when empty"`
+	RateLimitAuth   string        `config:"rate_limit_auth" default:"60/1m" usage:"requests to change products each IP address can make before authentication, limiting the credentials it can try, in the format requests/duration, not limited when empty"`
+}
+
+// Validate checks the settings can be used to start the API
+func (c *Config) Validate() error {
+	if c.ListenAddr == "" || c.ServerAddr == "" {
+		return fmt.Errorf("listen_addr and server_addr must be set")
+	}
+
+	if c.ReadTimeout <= 0 || c.WriteTimeout <= 0 || c.IdleTimeout <= 0 || c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("Timeouts must be greater than zero")
+	}
+
+	if hclog.LevelFromString(c.LogLevel) == hclog.NoLevel {
+		return fmt.Errorf("Unknown log level %s", c.LogLevel)
+	}
+
+	if (c.CurrencyCAFile != "" || c.CurrencyCert != "" || c.CurrencyKey != "" || c.CurrencyName != "") && !c.CurrencyTLS {
+		return fmt.Errorf("currency_tls must be enabled to use the currency TLS settings")
+	}
+
+	if (c.CurrencyCert == "") != (c.CurrencyKey == "") {
+		return fmt.Errorf("currency_cert_file and currency_key_file must be set together")
+	}
+
+	// tokens sent in plaintext could be read from the network
+	if c.CurrencyToken != "" && !c.CurrencyTLS {
+		return fmt.Errorf("currency_token requires currency_tls")
+	}
+
+	if _, _, _, err := c.rateLimits(); err != nil {
+		return err
+	}
+
+	switch c.Store {
+	case "memory":
+	case "sqlite":
+		if c.DBPath == "" {
+			return fmt.Errorf("db_path must be set when using the sqlite store")
+		}
+	default:
+		return fmt.Errorf("Unknown product store %s, expected memory or sqlite", c.Store)
+	}
+
+	return nil
+}
+
+// currencyCredentials returns the dial options which secure the connection to
+// the currency server
+func (c *Config) currencyCredentials() ([]grpc.DialOption, error) {
+	if !c.CurrencyTLS {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+
+	tc, err := security.ClientTLSConfig(c.CurrencyCAFile, c.CurrencyCert, c.CurrencyKey, c.CurrencyName)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tc))}
+	if c.CurrencyToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(security.BearerToken(c.CurrencyToken)))
+	}
+
+	return opts, nil
+}
+
+// rateLimits returns the limits of the requests to read and change products,
+// and of the requests each IP address can make before authentication
+func (c *Config) rateLimits() (reads ratelimit.Limit, writes ratelimit.Limit, authn ratelimit.Limit, err error) {
+	reads, err = ratelimit.ParseLimit(c.RateLimitReads)
+	if err != nil {
+		return reads, writes, authn, fmt.Errorf("rate_limit_reads: %w", err)
+	}
+
+	writes, err = ratelimit.ParseLimit(c.RateLimitWrites)
+	if err != nil {
+		return reads, writes, authn, fmt.Errorf("rate_limit_writes: %w", err)
+	}
+
+	authn, err = ratelimit.ParseLimit(c.RateLimitAuth)
+	if err != nil {
+		return reads, writes, authn, fmt.Errorf("rate_limit_auth: %w", err)
+	}
+
+	return reads, writes, authn, nil
+}
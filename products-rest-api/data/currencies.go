@@ -0,0 +1,63 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+)
+
+// Currency is a currency product prices can be converted to
+// swagger:model
+type Currency struct {
+	// the ISO 4217 currency code
+	//
+	// example: USD
+	Code string `json:"code"`
+
+	// the English name of the currency
+	//
+	// example: US Dollar
+	Name string `json:"name"`
+
+	// the symbol commonly used for the currency
+	//
+	// example: $
+	Symbol string `json:"symbol"`
+
+	// when the exchange rate for the currency was last updated
+	Updated time.Time `json:"updated"`
+
+	// the source of the exchange rate on the currency service
+	//
+	// example: ecb
+	Source string `json:"source"`
+}
+
+// ListCurrencies returns the currencies supported by the currency service
+// ordered by code
+func (p *ProductsDB) ListCurrencies(ctx context.Context) ([]*Currency, error) {
+	res, err := p.currency.ListCurrencies(ctx, &protos.ListCurrenciesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list currencies from currency server: %w", err)
+	}
+
+	cl := []*Currency{}
+	for _, ci := range res.GetCurrencies() {
+		updated, err := time.Parse(time.RFC3339, ci.GetUpdated())
+		if err != nil {
+			return nil, fmt.Errorf("Invalid update time from currency server, currency: %s, updated: %s: %w", ci.GetCode(), ci.GetUpdated(), err)
+		}
+
+		cl = append(cl, &Currency{
+			Code:    ci.GetCode(),
+			Name:    ci.GetName(),
+			Symbol:  ci.GetSymbol(),
+			Updated: updated,
+			Source:  ci.GetSource(),
+		})
+	}
+
+	return cl, nil
+}
@@ -0,0 +1,38 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCurrencies(t *testing.T) {
+	p := &ProductsDB{
+		currency: &fakeCurrencyClient{currencies: []*protos.CurrencyInfo{
+			{Code: "EUR", Name: "Euro", Symbol: "€", Updated: "2026-10-16T14:00:00Z", Source: "ecb"},
+			{Code: "USD", Name: "US Dollar", Symbol: "$", Updated: "2026-10-16T14:00:00Z", Source: "ecb"},
+		}},
+		log: hclog.NewNullLogger(),
+	}
+
+	cl, err := p.ListCurrencies(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, cl, 2)
+	assert.Equal(t, "USD", cl[1].Code)
+	assert.Equal(t, "US Dollar", cl[1].Name)
+	assert.Equal(t, time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC), cl[1].Updated)
+}
+
+func TestListCurrenciesRejectsInvalidUpdateTime(t *testing.T) {
+	p := &ProductsDB{
+		currency: &fakeCurrencyClient{currencies: []*protos.CurrencyInfo{{Code: "EUR", Updated: "yesterday"}}},
+		log:      hclog.NewNullLogger(),
+	}
+
+	_, err := p.ListCurrencies(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package data
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// MemoryStore is an implementation of the ProductStore interface which keeps
+// products in a slice, all data is lost when the process exits
+type MemoryStore struct {
+	mu       sync.RWMutex
+	products Products
+
+	// nextID is the id given to the next product added, ids are never
+	// reused even when the product with the highest id is deleted
+	nextID int
+}
+
+// NewMemoryStore creates a new MemoryStore seeded with the default products
+func NewMemoryStore() *MemoryStore {
+	ps := Products{}
+	nextID := 1
+	for _, p := range productList {
+		np := *p
+		ps = append(ps, &np)
+
+		if np.ID >= nextID {
+			nextID = np.ID + 1
+		}
+	}
+
+	return &MemoryStore{products: ps, nextID: nextID}
+}
+
+// List returns a copy of all products in the store
+func (m *MemoryStore) List() (Products, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ps := Products{}
+	for _, p := range m.products {
+		np := *p
+		ps = append(ps, &np)
+	}
+
+	return ps, nil
+}
+
+// Get returns a copy of the product with the given id
+func (m *MemoryStore) Get(id int) (*Product, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	i := m.findIndexByProductID(id)
+	if i == -1 {
+		return nil, ErrProductNotFound
+	}
+
+	np := *m.products[i]
+	return &np, nil
+}
+
+// Add appends the product to the store using the next id in sequence
+func (m *MemoryStore) Add(p *Product) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p.ID = m.nextID
+	p.Version = 1
+	m.nextID++
+
+	np := *p
+	m.products = append(m.products, &np)
+
+	return nil
+}
+
+// Update replaces the product with the same id as the given item
+func (m *MemoryStore) Update(p *Product) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.findIndexByProductID(p.ID)
+	if i == -1 {
+		return ErrProductNotFound
+	}
+
+	current := m.products[i].Version
+	if p.Version != 0 && p.Version != current {
+		return ErrVersionConflict
+	}
+	p.Version = current + 1
+
+	np := *p
+	m.products[i] = &np
+
+	return nil
+}
+
+// Delete removes the product with the given id
+func (m *MemoryStore) Delete(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.findIndexByProductID(id)
+	if i == -1 {
+		return ErrProductNotFound
+	}
+
+	m.products = append(m.products[:i], m.products[i+1:]...)
+
+	return nil
+}
+
+// findIndexByProductID finds the index of a product in the store
+// returns -1 when no product can be found, the caller must hold mu
+func (m *MemoryStore) findIndexByProductID(id int) int {
+	for i, p := range m.products {
+		if p.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+var productList = []*Product{
+	&Product{
+		ID:          1,
+		Name:        "Latte",
+		Description: "Frothy milky coffee",
+		Price:       NewMoney(decimal.RequireFromString("2.45"), BaseCurrency),
+		SKU:         "abc323",
+		Version:     1,
+	},
+	&Product{
+		ID:          2,
+		Name:        "Esspresso",
+		Description: "Short and strong coffee without milk",
+		Price:       NewMoney(decimal.RequireFromString("1.99"), BaseCurrency),
+		SKU:         "fjd34",
+		Version:     1,
+	},
+}
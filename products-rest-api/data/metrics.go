@@ -0,0 +1,14 @@
+package data
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// rateCacheRequests counts the lookups of the latest rates in the cache by
+// result, hit, stale or miss. Stale and missing rates are fetched from the
+// currency service
+var rateCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "products_rate_cache_requests_total",
+	Help: "Lookups of the latest exchange rate in the cache by result",
+}, []string{"result"})
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations contains the schema changes for the SQL store
+// each migration is applied once, in order, and its version recorded in the
+// schema_migrations table. Never edit a released migration, append a new one
+var migrations = []string{
+	// 1: create the products table
+	`CREATE TABLE products (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		price       REAL NOT NULL,
+		sku         TEXT NOT NULL DEFAULT ''
+	)`,
+
+	// 2: add the version used for optimistic locking
+	`ALTER TABLE products ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+
+	// 3: store prices as exact decimal strings with their currency, SQLite
+	// can not change the type of a column so the table is rebuilt
+	`CREATE TABLE products_new (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		price       TEXT NOT NULL,
+		currency    TEXT NOT NULL DEFAULT 'EUR',
+		sku         TEXT NOT NULL DEFAULT '',
+		version     INTEGER NOT NULL DEFAULT 1
+	);
+	INSERT INTO products_new (id, name, description, price, currency, sku, version)
+		SELECT id, name, description, printf('%.2f', price), 'EUR', sku, version FROM products;
+	DROP TABLE products;
+	ALTER TABLE products_new RENAME TO products`,
+}
+
+// migrate brings the schema of the given database up to date
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("Unable to create schema_migrations table: %w", err)
+	}
+
+	var current int
+	err = db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("Unable to read schema version: %w", err)
+	}
+
+	for v := current + 1; v <= len(migrations); v++ {
+		err := applyMigration(db, v, migrations[v-1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration and records its version in one transaction
+func applyMigration(db *sql.DB, version int, stmt string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(stmt); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("Unable to apply migration %d: %w", version, err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("Unable to record migration %d: %w", version, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/registry"
+	"github.com/shopspring/decimal"
+)
+
+// BaseCurrency is the currency product prices are stored in
+const BaseCurrency = "EUR"
+
+// ErrInvalidMoney is an error raised when a price can not be decoded
+var ErrInvalidMoney = fmt.Errorf("Invalid price, expected a number or an object with an amount and currency")
+
+// MinorUnits returns the number of decimal places used by the currency, from
+// ISO 4217. Unknown currencies use 2
+func MinorUnits(currency string) int32 {
+	return int32(registry.MinorUnits(currency))
+}
+
+// Money is an exact amount in a currency, the amount is always rounded to the
+// minor units of the currency
+// swagger:model
+type Money struct {
+	// the amount, rounded to the minor units of the currency
+	//
+	// required: true
+	// example: 2.45
+	Amount decimal.Decimal `json:"amount"`
+
+	// the ISO 4217 currency code
+	//
+	// required: true
+	// example: EUR
+	Currency string `json:"currency"`
+}
+
+// NewMoney returns the amount in the currency rounded to its minor units
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	return Money{Amount: amount.Round(MinorUnits(currency)), Currency: currency}
+}
+
+// Convert returns the amount multiplied by rate in the destination currency
+func (m Money) Convert(rate decimal.Decimal, currency string) Money {
+	return NewMoney(m.Amount.Mul(rate), currency)
+}
+
+// String returns the amount followed by the currency, e.g. 2.45 EUR
+func (m Money) String() string {
+	return m.Amount.StringFixed(MinorUnits(m.Currency)) + " " + m.Currency
+}
+
+// ParseMoney parses money in the format returned by String, e.g. 2.45 EUR,
+// when the currency is omitted the amount is in the BaseCurrency
+func ParseMoney(s string) (Money, error) {
+	f := strings.Fields(s)
+	if len(f) == 0 || len(f) > 2 {
+		return Money{}, ErrInvalidMoney
+	}
+
+	a, err := decimal.NewFromString(f[0])
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+
+	c := BaseCurrency
+	if len(f) == 2 {
+		c = f[1]
+	}
+
+	return NewMoney(a, c), nil
+}
+
+// MarshalJSON encodes the money as an object with the amount as a number
+// with the minor units of the currency, e.g. {"amount":2.45,"currency":"EUR"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, `{"amount":%s,"currency":`, m.Amount.StringFixed(MinorUnits(m.Currency)))
+
+	c, err := json.Marshal(m.Currency)
+	if err != nil {
+		return nil, err
+	}
+	b.Write(c)
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON decodes money from an object with an amount and currency, a
+// plain number is also accepted as an amount in the BaseCurrency. The amount
+// is rounded to the minor units of the currency
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	// plain number
+	if len(b) > 0 && b[0] != '{' {
+		var a decimal.Decimal
+		if err := a.UnmarshalJSON(b); err != nil {
+			return ErrInvalidMoney
+		}
+
+		*m = NewMoney(a, BaseCurrency)
+		return nil
+	}
+
+	var o struct {
+		Amount   *decimal.Decimal `json:"amount"`
+		Currency string           `json:"currency"`
+	}
+	if err := json.Unmarshal(b, &o); err != nil || o.Amount == nil {
+		return ErrInvalidMoney
+	}
+
+	if o.Currency == "" {
+		o.Currency = BaseCurrency
+	}
+
+	*m = NewMoney(*o.Amount, o.Currency)
+	return nil
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// eur returns the amount in EUR, panics if the amount is not a valid decimal
+func eur(amount string) Money {
+	return NewMoney(decimal.RequireFromString(amount), BaseCurrency)
+}
+
+func TestMoneyRoundsToMinorUnits(t *testing.T) {
+	m := eur("2.45").Convert(decimal.RequireFromString("1.14"), "USD")
+	assert.Equal(t, "2.79 USD", m.String())
+
+	m = eur("2.45").Convert(decimal.RequireFromString("129.53"), "JPY")
+	assert.Equal(t, "317 JPY", m.String())
+
+	m = eur("2.45").Convert(decimal.RequireFromString("158.9"), "ISK")
+	assert.Equal(t, "389 ISK", m.String())
+}
+
+func TestMoneyMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(eur("2.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":2.50,"currency":"EUR"}`, string(b))
+
+	b, err = json.Marshal(NewMoney(decimal.NewFromInt(317), "JPY"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":317,"currency":"JPY"}`, string(b))
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	var m Money
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":2.456,"currency":"USD"}`), &m))
+	assert.Equal(t, "2.46 USD", m.String())
+
+	// plain numbers are in the base currency
+	assert.NoError(t, json.Unmarshal([]byte(`1.99`), &m))
+	assert.Equal(t, "1.99 EUR", m.String())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":3}`), &m))
+	assert.Equal(t, "3.00 EUR", m.String())
+
+	assert.Equal(t, ErrInvalidMoney, json.Unmarshal([]byte(`{"currency":"USD"}`), &m))
+	assert.Equal(t, ErrInvalidMoney, json.Unmarshal([]byte(`"abc"`), &m))
+}
+
+func TestConvertPriceKeepsBasePrice(t *testing.T) {
+	p := &Product{Name: "Latte", Price: eur("2.45")}
+	p.convertPrice(Rate{Value: 1.1394}, "USD")
+
+	assert.Equal(t, "2.79 USD", p.Price.String())
+	assert.Equal(t, "2.45 EUR", p.BasePrice.String())
+}
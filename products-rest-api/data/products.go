@@ -3,16 +3,38 @@ package data
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
 	"github.com/hashicorp/go-hclog"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/shopspring/decimal"
 )
 
 // ErrProductNotFound is an error raised when a product can not be found in the database
 var ErrProductNotFound = fmt.Errorf("Product not found")
 
+// ErrHistoricalRateNotFound is an error raised when the currency service has no
+// rates published on or before the requested date
+var ErrHistoricalRateNotFound = fmt.Errorf("No exchange rates available on or before the requested date")
+
+// ErrUnknownCurrency is an error raised when the requested currency is not an
+// ISO 4217 currency code
+var ErrUnknownCurrency = fmt.Errorf("Unknown currency, expected an ISO 4217 code such as USD")
+
+// ErrInvalidRateRequest is an error raised when the currency service rejects a
+// rate request as invalid
+var ErrInvalidRateRequest = fmt.Errorf("Invalid exchange rate request")
+
+// ErrRateNotFound is an error raised when the currency service has no rate for
+// a valid ISO 4217 currency
+var ErrRateNotFound = fmt.Errorf("No exchange rate available for the currency")
+
+// ErrVersionConflict is an error raised when a product has been changed since
+// the version the caller based its update on
+var ErrVersionConflict = fmt.Errorf("Product has been modified, version does not match")
+
 // Product defines the structure for an API product
 // swagger:model
 type Product struct {
@@ -34,212 +56,293 @@ type Product struct {
 	// max length: 10000
 	Description string `json:"description"`
 
-	// the price for the product
+	// the price for the product, stored in EUR. When a currency is requested
+	// the price is converted and rounded to the minor units of the currency.
+	// A plain number is accepted as a price in EUR
 	//
 	// required: true
-	// min: 0.01
-	Price float64 `json:"price" validate:"required,gt=0"`
+	Price Money `json:"price" validate:"price"`
+
+	// the stored price the converted price was calculated from, only set when
+	// a currency is requested
+	//
+	// required: false
+	// read only: true
+	BasePrice *Money `json:"base_price,omitempty"`
 
 	// the SKU for the product
 	//
 	// required: false
-	// pattern: [a-z]+-[a-z]+-[a-z]+
-	SKU string `json:"sku" validate:"sku"`
+	// pattern: ^[a-z]+-[a-z]+-[a-z]+$
+	SKU string `json:"sku" validate:"omitempty,sku"`
+
+	// the version of the product, incremented every time it is updated
+	//
+	// required: false
+	// read only: true
+	Version int `json:"version"`
 }
 
 // Products defines a slice of Product
 type Products []*Product
 
+// ProductsDB provides access to the products in a ProductStore and converts
+// their prices using rates from the currency service.
+// ProductsDB is safe for concurrent use
 type ProductsDB struct {
 	currency protos.CurrencyClient
 	log      hclog.Logger
-	rates    map[string]float64
-	client   protos.Currency_SubscribeRatesClient
-}
+	store    ProductStore
+	index    *searchIndex
 
-func NewProductsDB(c protos.CurrencyClient, l hclog.Logger) *ProductsDB {
-	pb := &ProductsDB{c, l, make(map[string]float64), nil}
+	// writeMu serializes writes to the store with the matching index update
+	// so the index cannot be left holding an older version of a product
+	writeMu sync.Mutex
 
-	go pb.handleUpdates()
+	// mu guards rates, lastUsed, historical, client, connected and subscribed
+	// which are shared between the background goroutines and the request
+	// handlers
+	mu     sync.RWMutex
+	rates  map[string]Rate
+	client protos.Currency_SubscribeRatesClient
 
-	return pb
+	// connected is when the current rate stream was opened
+	connected time.Time
+
+	// subscribed records the currencies subscribed to on the current stream
+	subscribed map[string]bool
+
+	// sendMu serializes sends on the rate stream which does not support
+	// concurrent sends, it is held without mu so a send blocked by flow
+	// control does not block the requests reading the cache
+	sendMu sync.Mutex
+
+	// lastUsed records when each subscribed currency was last requested
+	lastUsed map[string]time.Time
+
+	// historical caches past rates by currency and date, only rates published
+	// on a date before today are cached as they never change
+	historical map[string]Rate
+
+	// stop cancels the background goroutines, done waits for them to exit
+	stop context.CancelFunc
+	done sync.WaitGroup
 }
 
-func (p *ProductsDB) handleUpdates() {
-	sub, err := p.currency.SubscribeRates(context.Background())
+// NewProductsDB creates a new ProductsDB backed by the given store
+func NewProductsDB(c protos.CurrencyClient, l hclog.Logger, s ProductStore) *ProductsDB {
+	pb := &ProductsDB{currency: c, log: l, store: s, index: newSearchIndex(), rates: make(map[string]Rate), lastUsed: make(map[string]time.Time), historical: make(map[string]Rate), subscribed: make(map[string]bool)}
+
+	// build the search index from the products already in the store
+	pl, err := s.List()
 	if err != nil {
-		p.log.Error("Cannot subcribe for rates", "error", err)
+		l.Error("Unable to build search index", "error", err)
+	}
+	for _, pr := range pl {
+		pb.index.add(pr)
 	}
 
-	p.client = sub
+	ctx, stop := context.WithCancel(context.Background())
+	pb.stop = stop
 
-	for {
-		rr, err := sub.Recv()
-		p.log.Info("Recevied updated rate from server", "dest", rr.GetDestination().String())
+	pb.done.Add(2)
+	go func() {
+		defer pb.done.Done()
+		pb.handleUpdates(ctx)
+	}()
+	go func() {
+		defer pb.done.Done()
+		pb.pruneSubscriptions(ctx, SubscriptionIdleTimeout)
+	}()
 
-		if err != nil {
-			p.log.Error("Error receving message", "error", err)
-			return
-		}
-		p.rates[rr.Destination.String()] = rr.Rate
-	}
+	return pb
 }
 
-// GetProducts returns all products from the database
-func (p *ProductsDB) GetProducts(currency string) (Products, error) {
+// Close closes the rate stream and stops the background goroutines started
+// by NewProductsDB, it returns once they have exited
+func (p *ProductsDB) Close() {
+	p.stop()
+	p.done.Wait()
+}
 
-	// If currency not specified return productList
-	if currency == "" {
-		return productList, nil
+// GetProducts returns a page of products from the database matching the query
+// prices are converted to the given currency before the query is applied, when
+// asOf is not zero using the rates published on that date
+func (p *ProductsDB) GetProducts(ctx context.Context, currency string, asOf time.Time, q *ProductQuery) (*ProductPage, error) {
+	if q == nil {
+		q = &ProductQuery{}
 	}
 
-	rate, err := p.getRate(currency)
+	var pl Products
+	err := traceStore(ctx, "List", func() (err error) {
+		pl, err = p.store.List()
+		return err
+	})
 	if err != nil {
-		p.log.Error("Unable to get rate", "currency", currency, "error", err)
 		return nil, err
 	}
 
-	// Create new productList for response
-	// Loop over productList multiplying price by response rate and append to new productList
-	plr := Products{}
-	for _, p := range productList {
-		np := *p
-		np.Price = np.Price * rate
-		plr = append(plr, &np)
+	// If currency not specified filter the stored prices
+	if currency == "" {
+		return q.apply(pl)
+	}
+
+	rate, err := p.getRateAt(ctx, currency, asOf)
+	if err != nil {
+		requestlog.Logger(ctx, p.log).Error("Unable to get rate", "currency", currency, "error", err)
+		return nil, err
+	}
 
+	// Loop over the products converting the price with the response rate,
+	// the store returns copies so the underlying data is not changed
+	for _, pr := range pl {
+		pr.convertPrice(rate, currency)
 	}
 
-	// return new product list
-	return plr, nil
+	page, err := q.apply(pl)
+	if err != nil {
+		return nil, err
+	}
+	page.Rate = &rate
 
+	return page, nil
 }
 
 // GetProductByID returns a single product which matches the id from the
-// database.
+// database and the rate used to convert its price, the rate is nil when no
+// currency is given.
 // If a product is not found this function returns a ProductNotFound error
-func (p *ProductsDB) GetProductByID(id int, currency string) (*Product, error) {
-
-	i := findIndexByProductID(id)
-	if id == -1 {
-		return nil, ErrProductNotFound
+func (p *ProductsDB) GetProductByID(ctx context.Context, id int, currency string, asOf time.Time) (*Product, *Rate, error) {
+	pr, err := p.getProduct(ctx, id)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if currency == "" {
-		return productList[i], nil
+		return pr, nil, nil
 	}
 
-	rate, err := p.getRate(currency)
+	rate, err := p.getRateAt(ctx, currency, asOf)
 	if err != nil {
-		p.log.Error("Unable to get rate", "currency", currency, "error", err)
-		return nil, err
+		requestlog.Logger(ctx, p.log).Error("Unable to get rate", "currency", currency, "error", err)
+		return nil, nil, err
 	}
-	// Need to make copy of productList as it has reference to collection, mutating it would
-	// change underlining data
 
-	np := *productList[i]
-	np.Price = np.Price * rate
+	pr.convertPrice(rate, currency)
 
-	return &np, nil
+	return pr, &rate, nil
 }
 
 // UpdateProduct replaces a product in the database with the given
 // item.
 // If a product with the given id does not exist in the database
-// this function returns a ProductNotFound error
-func (p *ProductsDB) UpdateProduct(pr *Product) error {
-	i := findIndexByProductID(pr.ID)
-	if i == -1 {
-		return ErrProductNotFound
+// this function returns a ProductNotFound error.
+// When the items Version is not zero it must match the stored version or a
+// VersionConflict error is returned, on success Version is set to the new version
+func (p *ProductsDB) UpdateProduct(ctx context.Context, pr *Product) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	err := traceStore(ctx, "Update", func() error {
+		return p.store.Update(pr)
+	})
+	if err != nil {
+		return err
 	}
-	// update the product in the DB
-	productList[i] = pr
+
+	p.index.add(pr)
 
 	return nil
 }
 
 // AddProduct adds a new product to the database
-func (p *ProductsDB) AddProduct(pr *Product) {
-	// get the next id in sequence
-	maxID := productList[len(productList)-1].ID
-	pr.ID = maxID + 1
-
-	productList = append(productList, pr)
-}
+func (p *ProductsDB) AddProduct(ctx context.Context, pr *Product) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
 
-// DeleteProduct deletes a product from the database
-func DeleteProduct(id int) error {
-	i := findIndexByProductID(id)
-	if i == -1 {
-		return ErrProductNotFound
+	err := traceStore(ctx, "Add", func() error {
+		return p.store.Add(pr)
+	})
+	if err != nil {
+		return err
 	}
 
-	productList = append(productList[:i], productList[i+1])
+	p.index.add(pr)
 
 	return nil
 }
 
-// findIndex finds the index of a product in the database
-// returns -1 when no product can be found
-func findIndexByProductID(id int) int {
-	for i, p := range productList {
-		if p.ID == id {
-			return i
-		}
+// DeleteProduct deletes a product from the database
+func (p *ProductsDB) DeleteProduct(ctx context.Context, id int) error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	err := traceStore(ctx, "Delete", func() error {
+		return p.store.Delete(id)
+	})
+	if err != nil {
+		return err
 	}
 
-	return -1
-}
+	p.index.remove(id)
 
-func (p *ProductsDB) getRate(destination string) (float64, error) {
+	return nil
+}
 
-	// If cached, return
-	if r, ok := p.rates[destination]; ok {
-		return r, nil
-	}
-	// Construct request with base "EUR" to destination specificed
-	rr := &protos.RateRequest{
-		Base:        protos.Currencies(protos.Currencies_value["EUR"]),
-		Destination: protos.Currencies(protos.Currencies_value[destination]),
-	}
-	// get initial req rate using GetRate
-	res, err := p.currency.GetRate(context.Background(), rr)
+// SearchProducts returns the products whose name or description match the
+// query ordered by relevance, prices are converted to the given currency.
+// The rate used is returned, nil when no prices were converted
+func (p *ProductsDB) SearchProducts(ctx context.Context, query string, currency string, asOf time.Time) ([]*SearchResult, *Rate, error) {
+	hits, err := p.index.search(query)
 	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			md := s.Details()[0].(*protos.RateRequest)
-
-			if s.Code() == codes.InvalidArgument {
-				return -1, fmt.Errorf("Unable to get rate from currency server, destination and base currency cannot be the same, base: %s, dest: %s", md.Base.String(), md.Destination.String())
+		return nil, nil, err
+	}
 
-			}
-			return -1, fmt.Errorf("Unable to get rate from currency server, base: %s, dest: %s", md.Base.String(), md.Destination.String())
+	var rate *Rate
+	if currency != "" && len(hits) > 0 {
+		r, err := p.getRateAt(ctx, currency, asOf)
+		if err != nil {
+			requestlog.Logger(ctx, p.log).Error("Unable to get rate", "currency", currency, "error", err)
+			return nil, nil, err
+		}
+		rate = &r
+	}
 
+	res := []*SearchResult{}
+	for _, h := range hits {
+		pr, err := p.getProduct(ctx, h.id)
+		if err == ErrProductNotFound {
+			// deleted since the index was searched
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
 		}
-		return -1, err
 
+		if rate != nil {
+			pr.convertPrice(*rate, currency)
+		}
+		res = append(res, &SearchResult{Score: h.score, Product: pr})
 	}
-	p.rates[destination] = res.Rate // update cache
 
-	// subscribe for updates
-	p.client.Send(rr)
+	return res, rate, nil
+}
 
-	//	err = p.currency.SubscribeRates(context.Background(), rr)
+// getProduct returns a copy of the product with the given id from the store
+func (p *ProductsDB) getProduct(ctx context.Context, id int) (*Product, error) {
+	var pr *Product
+	err := traceStore(ctx, "Get", func() (err error) {
+		pr, err = p.store.Get(id)
+		return err
+	})
 
-	return res.Rate, err
+	return pr, err
 }
 
-var productList = []*Product{
-	&Product{
-		ID:          1,
-		Name:        "Latte",
-		Description: "Frothy milky coffee",
-		Price:       2.45,
-		SKU:         "abc323",
-	},
-	&Product{
-		ID:          2,
-		Name:        "Esspresso",
-		Description: "Short and strong coffee without milk",
-		Price:       1.99,
-		SKU:         "fjd34",
-	},
+// convertPrice converts the price to the currency keeping the stored price as
+// the BasePrice
+func (p *Product) convertPrice(rate Rate, currency string) {
+	base := p.Price
+	p.Price = base.Convert(decimal.NewFromFloat(rate.Value), currency)
+	p.BasePrice = &base
 }
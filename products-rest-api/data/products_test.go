@@ -2,14 +2,20 @@ package data
 
 import (
 	"bytes"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestProductMissingNameReturnsErr(t *testing.T) {
 	p := Product{
-		Price: 1.22,
+		Price: eur("1.22"),
 	}
 
 	v := NewValidation()
@@ -20,7 +26,7 @@ func TestProductMissingNameReturnsErr(t *testing.T) {
 func TestProductMissingPriceReturnsErr(t *testing.T) {
 	p := Product{
 		Name:  "abc",
-		Price: -1,
+		Price: eur("-1"),
 	}
 
 	v := NewValidation()
@@ -31,7 +37,7 @@ func TestProductMissingPriceReturnsErr(t *testing.T) {
 func TestProductInvalidSKUReturnsErr(t *testing.T) {
 	p := Product{
 		Name:  "abc",
-		Price: 1.22,
+		Price: eur("1.22"),
 		SKU:   "abc",
 	}
 
@@ -40,16 +46,32 @@ func TestProductInvalidSKUReturnsErr(t *testing.T) {
 	assert.Len(t, err, 1)
 }
 
+func TestProductSKUFormat(t *testing.T) {
+	v := NewValidation()
+
+	valid := []string{"", "abc-efg-hji", "a-b-c"}
+	for _, sku := range valid {
+		err := v.Validate(Product{Name: "abc", Price: eur("1.22"), SKU: sku})
+		assert.Len(t, err, 0, sku)
+	}
+
+	invalid := []string{"abc-efg", "abc-efg-hji-jkl", "xxABC-def-ghi-jkl!!", "abc-def-ghi!", " abc-def-ghi", "ABC-DEF-GHI", "abc-def-ghi\n"}
+	for _, sku := range invalid {
+		err := v.Validate(Product{Name: "abc", Price: eur("1.22"), SKU: sku})
+		assert.Len(t, err, 1, sku)
+	}
+}
+
 func TestValidProductDoesNOTReturnsErr(t *testing.T) {
 	p := Product{
 		Name:  "abc",
-		Price: 1.22,
+		Price: eur("1.22"),
 		SKU:   "abc-efg-hji",
 	}
 
 	v := NewValidation()
 	err := v.Validate(p)
-	assert.Len(t, err, 1)
+	assert.Len(t, err, 0)
 }
 
 func TestProductsToJSON(t *testing.T) {
@@ -63,3 +85,278 @@ func TestProductsToJSON(t *testing.T) {
 	err := ToJSON(ps, b)
 	assert.NoError(t, err)
 }
+
+func TestMemoryStoreConformance(t *testing.T) {
+	testProductStore(t, func(t *testing.T) ProductStore {
+		return NewMemoryStore()
+	})
+}
+
+func TestSQLiteStoreConformance(t *testing.T) {
+	testProductStore(t, func(t *testing.T) ProductStore {
+		dir, err := ioutil.TempDir("", "products")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(dir) })
+
+		s, err := NewSQLiteStore(filepath.Join(dir, "products.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { s.Close() })
+
+		return s
+	})
+}
+
+func TestSQLiteStorePersistsBetweenOpens(t *testing.T) {
+	dir, err := ioutil.TempDir("", "products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "products.db")
+	s, err := NewSQLiteStore(path)
+	assert.NoError(t, err)
+
+	p := &Product{Name: "Latte", Price: eur("2.45")}
+	assert.NoError(t, s.Add(p))
+	assert.NoError(t, s.Close())
+
+	// reopening must not re-run migrations or lose data
+	s, err = NewSQLiteStore(path)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	got, err := s.Get(p.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Latte", got.Name)
+}
+
+func TestSQLiteMigrationConvertsPricesToDecimal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "products")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "products.db")
+
+	// create a database with the float prices used before migration 3
+	db, err := sql.Open("sqlite3", path)
+	assert.NoError(t, err)
+	all := migrations
+	migrations = all[:2]
+	err = migrate(db)
+	migrations = all
+	assert.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO products (name, price, version) VALUES ('Latte', 2.45, 3)`)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	s, err := NewSQLiteStore(path)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	got, err := s.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.45 EUR", got.Price.String())
+	assert.Equal(t, 3, got.Version)
+
+	// ids keep increasing after the table is rebuilt
+	p := &Product{Name: "Mocha", Price: eur("2.99")}
+	assert.NoError(t, s.Add(p))
+	assert.Equal(t, 2, p.ID)
+}
+
+// testProductStore is the conformance suite every ProductStore implementation must pass
+func testProductStore(t *testing.T, newStore func(t *testing.T) ProductStore) {
+	t.Run("AddAssignsUniqueIDs", func(t *testing.T) {
+		s := newStore(t)
+
+		p1 := &Product{Name: "Mocha", Price: eur("2.99"), SKU: "abc-def-ghi"}
+		p2 := &Product{Name: "Cortado", Price: eur("2.10")}
+		assert.NoError(t, s.Add(p1))
+		assert.NoError(t, s.Add(p2))
+
+		assert.NotZero(t, p1.ID)
+		assert.NotEqual(t, p1.ID, p2.ID)
+	})
+
+	t.Run("GetReturnsAddedProduct", func(t *testing.T) {
+		s := newStore(t)
+
+		p := &Product{Name: "Mocha", Description: "Chocolate coffee", Price: eur("2.99"), SKU: "abc-def-ghi"}
+		assert.NoError(t, s.Add(p))
+
+		got, err := s.Get(p.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, p, got)
+	})
+
+	t.Run("GetMissingReturnsErr", func(t *testing.T) {
+		s := newStore(t)
+
+		_, err := s.Get(100000)
+		assert.Equal(t, ErrProductNotFound, err)
+	})
+
+	t.Run("ListContainsAddedProducts", func(t *testing.T) {
+		s := newStore(t)
+
+		before, err := s.List()
+		assert.NoError(t, err)
+
+		p := &Product{Name: "Mocha", Price: eur("2.99")}
+		assert.NoError(t, s.Add(p))
+
+		after, err := s.List()
+		assert.NoError(t, err)
+		assert.Len(t, after, len(before)+1)
+		assert.Equal(t, p, after[len(after)-1])
+	})
+
+	t.Run("ReturnedProductsAreCopies", func(t *testing.T) {
+		s := newStore(t)
+
+		p := &Product{Name: "Mocha", Price: eur("2.99")}
+		assert.NoError(t, s.Add(p))
+		p.Name = "Changed"
+
+		got, err := s.Get(p.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Mocha", got.Name)
+
+		got.Price = eur("100")
+		ps, err := s.List()
+		assert.NoError(t, err)
+		assert.Equal(t, "2.99 EUR", ps[len(ps)-1].Price.String())
+	})
+
+	t.Run("UpdateReplacesProduct", func(t *testing.T) {
+		s := newStore(t)
+
+		p := &Product{Name: "Mocha", Price: eur("2.99")}
+		assert.NoError(t, s.Add(p))
+
+		u := &Product{ID: p.ID, Name: "White Mocha", Price: eur("3.20"), SKU: "abc-def-ghi"}
+		assert.NoError(t, s.Update(u))
+
+		got, err := s.Get(p.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, u, got)
+	})
+
+	t.Run("UpdateMissingReturnsErr", func(t *testing.T) {
+		s := newStore(t)
+
+		err := s.Update(&Product{ID: 100000, Name: "Mocha", Price: eur("2.99")})
+		assert.Equal(t, ErrProductNotFound, err)
+	})
+
+	t.Run("UpdateIncrementsVersion", func(t *testing.T) {
+		s := newStore(t)
+
+		p := &Product{Name: "Mocha", Price: eur("2.99")}
+		assert.NoError(t, s.Add(p))
+		assert.Equal(t, 1, p.Version)
+
+		// version zero skips the check
+		u := &Product{ID: p.ID, Name: "Mocha", Price: eur("3.10")}
+		assert.NoError(t, s.Update(u))
+		assert.Equal(t, 2, u.Version)
+
+		u.Price = eur("3.20")
+		assert.NoError(t, s.Update(u))
+		assert.Equal(t, 3, u.Version)
+
+		got, err := s.Get(p.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, got.Version)
+	})
+
+	t.Run("UpdateStaleVersionReturnsErr", func(t *testing.T) {
+		s := newStore(t)
+
+		p := &Product{Name: "Mocha", Price: eur("2.99")}
+		assert.NoError(t, s.Add(p))
+
+		first := &Product{ID: p.ID, Name: "Mocha", Price: eur("3.10"), Version: 1}
+		stale := &Product{ID: p.ID, Name: "Mocha", Price: eur("3.50"), Version: 1}
+		assert.NoError(t, s.Update(first))
+		assert.Equal(t, ErrVersionConflict, s.Update(stale))
+
+		got, err := s.Get(p.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "3.10 EUR", got.Price.String())
+	})
+
+	t.Run("ConcurrentUpdatesOnlyOneWins", func(t *testing.T) {
+		s := newStore(t)
+
+		p := &Product{Name: "Mocha", Price: eur("2.99")}
+		assert.NoError(t, s.Add(p))
+
+		const writers = 10
+		errs := make(chan error, writers)
+		var wg sync.WaitGroup
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs <- s.Update(&Product{ID: p.ID, Name: "Mocha", Price: NewMoney(decimal.NewFromInt(int64(i+1)), BaseCurrency), Version: 1})
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		ok := 0
+		for err := range errs {
+			if err == nil {
+				ok++
+				continue
+			}
+			assert.Equal(t, ErrVersionConflict, err)
+		}
+		assert.Equal(t, 1, ok)
+	})
+
+	t.Run("DeleteRemovesOnlyThatProduct", func(t *testing.T) {
+		s := newStore(t)
+
+		p1 := &Product{Name: "Mocha", Price: eur("2.99")}
+		p2 := &Product{Name: "Cortado", Price: eur("2.10")}
+		p3 := &Product{Name: "Flat White", Price: eur("2.50")}
+		assert.NoError(t, s.Add(p1))
+		assert.NoError(t, s.Add(p2))
+		assert.NoError(t, s.Add(p3))
+
+		assert.NoError(t, s.Delete(p2.ID))
+
+		_, err := s.Get(p2.ID)
+		assert.Equal(t, ErrProductNotFound, err)
+
+		got, err := s.Get(p3.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, p3, got)
+
+		assert.Equal(t, ErrProductNotFound, s.Delete(p2.ID))
+	})
+
+	t.Run("DeletedIDsAreNotReused", func(t *testing.T) {
+		s := newStore(t)
+
+		p1 := &Product{Name: "Mocha", Price: eur("2.99")}
+		p2 := &Product{Name: "Cortado", Price: eur("2.10")}
+		assert.NoError(t, s.Add(p1))
+		assert.NoError(t, s.Add(p2))
+
+		// deleting the newest product must not free its id for the next add
+		assert.NoError(t, s.Delete(p2.ID))
+
+		p3 := &Product{Name: "Flat White", Price: eur("2.50")}
+		assert.NoError(t, s.Add(p3))
+		assert.NotEqual(t, p2.ID, p3.ID)
+		assert.NotEqual(t, p1.ID, p3.ID)
+	})
+}
@@ -0,0 +1,188 @@
+package data
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxPageSize is the largest number of products returned in a single page
+const MaxPageSize = 100
+
+// ErrInvalidCursor is an error raised when a pagination cursor can not be decoded
+var ErrInvalidCursor = fmt.Errorf("Invalid cursor, use the cursor from the Link header of the previous page")
+
+// ErrInvalidLimit is an error raised when the limit is larger than MaxPageSize
+var ErrInvalidLimit = fmt.Errorf("Invalid limit, expected at most %d products", MaxPageSize)
+
+// ErrInvalidSort is an error raised when the sort order is not supported
+var ErrInvalidSort = fmt.Errorf("Invalid sort, expected one of id, name, price optionally prefixed with -")
+
+// ProductQuery defines how a list of products is filtered, sorted and paged
+// the zero value returns every product ordered by id
+type ProductQuery struct {
+	// Limit is the maximum number of products to return, at most
+	// MaxPageSize, 0 returns all
+	Limit int
+
+	// Cursor is the opaque position returned as NextCursor by a previous page
+	Cursor string
+
+	// Sort is the field to order by, id, name or price, prefixed with - for
+	// descending order
+	Sort string
+
+	// NameContains only includes products whose name contains the string,
+	// ignoring case
+	NameContains string
+
+	// MinPrice and MaxPrice only include products priced within the range,
+	// inclusive, after any currency conversion. Nil means no limit
+	MinPrice *decimal.Decimal
+	MaxPrice *decimal.Decimal
+
+	// SKU only includes the product with the given SKU
+	SKU string
+}
+
+// ProductPage is a single page of products returned by a ProductQuery
+type ProductPage struct {
+	// Products in this page
+	Products Products
+
+	// Total number of products matching the query across all pages
+	Total int
+
+	// NextCursor fetches the following page, empty when this is the last page
+	NextCursor string
+
+	// Rate used to convert the prices, nil when prices are not converted
+	Rate *Rate
+}
+
+// apply filters, sorts and pages the given products
+func (q *ProductQuery) apply(ps Products) (*ProductPage, error) {
+	if q.Limit > MaxPageSize {
+		return nil, ErrInvalidLimit
+	}
+
+	field, desc, err := q.sortField()
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := decodeCursor(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// filter
+	fl := Products{}
+	for _, p := range ps {
+		if q.matches(p) {
+			fl = append(fl, p)
+		}
+	}
+
+	// sort, using the id to break ties so pages are stable
+	sort.SliceStable(fl, func(i, j int) bool {
+		a, b := fl[i], fl[j]
+		if desc {
+			a, b = b, a
+		}
+
+		switch field {
+		case "name":
+			an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name)
+			if an != bn {
+				return an < bn
+			}
+		case "price":
+			if c := a.Price.Amount.Cmp(b.Price.Amount); c != 0 {
+				return c < 0
+			}
+		}
+
+		return a.ID < b.ID
+	})
+
+	// page
+	page := &ProductPage{Total: len(fl), Products: Products{}}
+	if offset >= len(fl) {
+		return page, nil
+	}
+
+	end := len(fl)
+	if q.Limit > 0 && offset+q.Limit < end {
+		end = offset + q.Limit
+		page.NextCursor = encodeCursor(end)
+	}
+	page.Products = fl[offset:end]
+
+	return page, nil
+}
+
+// matches returns true when the product passes all of the query filters
+func (q *ProductQuery) matches(p *Product) bool {
+	if q.NameContains != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(q.NameContains)) {
+		return false
+	}
+
+	if q.MinPrice != nil && p.Price.Amount.LessThan(*q.MinPrice) {
+		return false
+	}
+
+	if q.MaxPrice != nil && p.Price.Amount.GreaterThan(*q.MaxPrice) {
+		return false
+	}
+
+	if q.SKU != "" && p.SKU != q.SKU {
+		return false
+	}
+
+	return true
+}
+
+// sortField returns the field to sort by and whether the order is descending
+func (q *ProductQuery) sortField() (string, bool, error) {
+	field := strings.TrimPrefix(q.Sort, "-")
+	desc := strings.HasPrefix(q.Sort, "-")
+
+	switch field {
+	case "":
+		return "id", desc, nil
+	case "id", "name", "price":
+		return field, desc, nil
+	}
+
+	return "", false, ErrInvalidSort
+}
+
+// encodeCursor returns an opaque cursor for the given offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset stored in a cursor, an empty cursor is the
+// first page
+func decodeCursor(c string) (int, error) {
+	if c == "" {
+		return 0, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryProducts() Products {
+	return Products{
+		&Product{ID: 1, Name: "Latte", Price: eur("2.45"), SKU: "abc-abc-abc"},
+		&Product{ID: 2, Name: "Esspresso", Price: eur("1.99"), SKU: "def-def-def"},
+		&Product{ID: 3, Name: "Iced Latte", Price: eur("2.95"), SKU: "ghi-ghi-ghi"},
+		&Product{ID: 4, Name: "Mocha", Price: eur("2.45"), SKU: "jkl-jkl-jkl"},
+	}
+}
+
+func productIDs(ps Products) []int {
+	ids := []int{}
+	for _, p := range ps {
+		ids = append(ids, p.ID)
+	}
+
+	return ids
+}
+
+func TestQueryZeroValueReturnsAllByID(t *testing.T) {
+	q := &ProductQuery{}
+
+	page, err := q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, productIDs(page.Products))
+	assert.Equal(t, 4, page.Total)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestQuerySortsByPriceBreakingTiesByID(t *testing.T) {
+	q := &ProductQuery{Sort: "price"}
+	page, err := q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 4, 3}, productIDs(page.Products))
+
+	q = &ProductQuery{Sort: "-price"}
+	page, err = q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 1, 2}, productIDs(page.Products))
+}
+
+func TestQuerySortsByName(t *testing.T) {
+	q := &ProductQuery{Sort: "name"}
+
+	page, err := q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 1, 4}, productIDs(page.Products))
+}
+
+func TestQueryInvalidSortReturnsErr(t *testing.T) {
+	q := &ProductQuery{Sort: "sku"}
+
+	_, err := q.apply(queryProducts())
+	assert.Equal(t, ErrInvalidSort, err)
+}
+
+func TestQueryFilters(t *testing.T) {
+	q := &ProductQuery{NameContains: "LATTE"}
+	page, err := q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, productIDs(page.Products))
+
+	lo, hi := decimal.NewFromInt(2), decimal.RequireFromString("2.45")
+	q = &ProductQuery{MinPrice: &lo, MaxPrice: &hi}
+	page, err = q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 4}, productIDs(page.Products))
+
+	// zero is a limit, not the absence of one
+	zero := decimal.Zero
+	q = &ProductQuery{MaxPrice: &zero}
+	page, err = q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Empty(t, page.Products)
+
+	q = &ProductQuery{MinPrice: &zero}
+	page, err = q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Len(t, page.Products, 4)
+
+	q = &ProductQuery{SKU: "def-def-def"}
+	page, err = q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, productIDs(page.Products))
+}
+
+func TestQueryLimitOverMaxPageSizeReturnsErr(t *testing.T) {
+	q := &ProductQuery{Limit: MaxPageSize + 1}
+
+	_, err := q.apply(queryProducts())
+	assert.Equal(t, ErrInvalidLimit, err)
+}
+
+func TestQueryPagesWithCursor(t *testing.T) {
+	q := &ProductQuery{Limit: 3, Sort: "-price"}
+
+	page, err := q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 1}, productIDs(page.Products))
+	assert.Equal(t, 4, page.Total)
+	assert.NotEmpty(t, page.NextCursor)
+
+	q.Cursor = page.NextCursor
+	page, err = q.apply(queryProducts())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, productIDs(page.Products))
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestQueryInvalidCursorReturnsErr(t *testing.T) {
+	q := &ProductQuery{Cursor: "not a cursor"}
+
+	_, err := q.apply(queryProducts())
+	assert.Equal(t, ErrInvalidCursor, err)
+}
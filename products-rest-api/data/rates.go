@@ -0,0 +1,436 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/JamieBShaw/golang-mux-rest-api/currency/registry"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateTTL is how long a cached rate is used before it is fetched again from
+// the currency service unless it is kept fresh by the rate stream. Rates
+// subscribed to and cached since the stream connected do not expire
+const RateTTL = time.Minute
+
+// SubscriptionIdleTimeout is how long a currency can go without being
+// requested before ProductsDB unsubscribes from its rate updates
+const SubscriptionIdleTimeout = 10 * time.Minute
+
+// delays between attempts to reconnect the rate stream, doubling after every
+// failed attempt
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// ErrRateStreamDisconnected is an error raised when the rate stream to the
+// currency service is not connected
+var ErrRateStreamDisconnected = fmt.Errorf("Rate stream to the currency server is not connected")
+
+// Rate is an exchange rate from EUR returned by the currency service
+type Rate struct {
+	// Value is the amount of the currency equal to one EUR
+	Value float64
+
+	// Updated is when the rate was received from the currency service, for
+	// historical rates the date the rate was published
+	Updated time.Time
+
+	// SnapshotID identifies the set of rates on the currency service the rate
+	// was taken from, empty for historical rates
+	SnapshotID string
+}
+
+// handleUpdates keeps a rate stream open to the currency service until the
+// context is cancelled, when the stream fails it is reconnected with
+// exponential backoff
+func (p *ProductsDB) handleUpdates(ctx context.Context) {
+	delay := minReconnectDelay
+
+	for {
+		received, err := p.streamRates(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// the stream was working so start backing off from the beginning
+		if received {
+			delay = minReconnectDelay
+		}
+
+		p.log.Error("Rate stream closed, reconnecting", "error", err, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay = nextReconnectDelay(delay)
+	}
+}
+
+// CheckRateStream returns an ErrRateStreamDisconnected error while the rate
+// stream is down, cached rates are not updated until it reconnects
+func (p *ProductsDB) CheckRateStream() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.client == nil {
+		return ErrRateStreamDisconnected
+	}
+
+	return nil
+}
+
+// nextReconnectDelay returns the delay to use after the given delay fails
+func nextReconnectDelay(d time.Duration) time.Duration {
+	d *= 2
+	if d > maxReconnectDelay {
+		return maxReconnectDelay
+	}
+
+	return d
+}
+
+// streamRates opens a rate stream, subscribes to the cached currencies and
+// applies the updates until the stream fails. Returns true when any message
+// was received on the stream. The stream is closed when ctx is cancelled
+func (p *ProductsDB) streamRates(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub, err := p.currency.SubscribeRates(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// subscriptions do not survive the stream so subscribe again to the
+	// currencies cached before it was reconnected
+	p.mu.Lock()
+	p.client = sub
+	p.connected = time.Now()
+	p.subscribed = map[string]bool{}
+	dests := []string{}
+	for dest := range p.rates {
+		dests = append(dests, dest)
+	}
+	p.mu.Unlock()
+
+	for _, dest := range dests {
+		p.subscribe(dest)
+	}
+
+	defer func() {
+		p.mu.Lock()
+		p.client = nil
+		p.subscribed = map[string]bool{}
+		p.mu.Unlock()
+	}()
+
+	received := false
+	for {
+		res, err := sub.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		switch m := res.GetMessage().(type) {
+		case *protos.SubscribeRatesResponse_RateResponse:
+			rr := m.RateResponse
+			p.log.Info("Recevied updated rate from server", "dest", rr.GetDestination())
+
+			p.mu.Lock()
+			// ignore updates already in flight when the currency was unsubscribed
+			if _, ok := p.rates[rr.GetDestination()]; ok {
+				p.rates[rr.GetDestination()] = Rate{Value: rr.GetRate(), Updated: time.Now(), SnapshotID: rr.GetSnapshotID()}
+			}
+			p.mu.Unlock()
+
+		case *protos.SubscribeRatesResponse_Ack:
+			p.log.Debug("Subscription changed", "dest", m.Ack.GetRequest().GetDestination(), "result", m.Ack.GetResult().String())
+
+		case *protos.SubscribeRatesResponse_Subscriptions:
+			p.log.Debug("Subscribed rates", "subscriptions", m.Subscriptions.GetSubscriptions())
+
+		case *protos.SubscribeRatesResponse_Error:
+			p.handleStreamError(status.FromProto(m.Error))
+		}
+	}
+}
+
+// subscribe subscribes to updates for the destination currency when the
+// stream is connected, p.mu must not be held
+func (p *ProductsDB) subscribe(destination string) {
+	p.mu.RLock()
+	client := p.client
+	p.mu.RUnlock()
+
+	if client == nil {
+		return
+	}
+
+	err := p.send(client, &protos.SubscribeRatesRequest{
+		Message: &protos.SubscribeRatesRequest_Subscribe{Subscribe: rateRequest(destination)},
+	})
+	if err != nil {
+		p.log.Error("Unable to subscribe to rate", "dest", destination, "error", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// the stream may have been replaced, or the rate removed, while sending
+	if _, ok := p.rates[destination]; ok && p.client == client {
+		p.subscribed[destination] = true
+	}
+}
+
+// send sends the request on the rate stream, sends are serialized by sendMu
+// as the stream does not support concurrent sends
+func (p *ProductsDB) send(client protos.Currency_SubscribeRatesClient, req *protos.SubscribeRatesRequest) error {
+	p.sendMu.Lock()
+	defer p.sendMu.Unlock()
+
+	return client.Send(req)
+}
+
+// handleStreamError handles a subscription the currency service rejected, the
+// cached rate is removed as it will no longer be updated and the next request
+// for the currency fetches the rate again
+func (p *ProductsDB) handleStreamError(s *status.Status) {
+	for _, d := range s.Details() {
+		rr, ok := d.(*protos.RateRequest)
+		if !ok {
+			continue
+		}
+
+		dest := rr.GetDestination()
+		p.log.Error("Subscription rejected by currency server", "dest", dest, "code", s.Code().String(), "error", s.Message())
+
+		p.mu.Lock()
+		delete(p.rates, dest)
+		delete(p.lastUsed, dest)
+		delete(p.subscribed, dest)
+		p.mu.Unlock()
+
+		return
+	}
+
+	p.log.Error("Error from currency server", "code", s.Code().String(), "error", s.Message())
+}
+
+// pruneSubscriptions periodically unsubscribes from the currencies which have
+// not been requested within the timeout until the context is cancelled
+func (p *ProductsDB) pruneSubscriptions(ctx context.Context, timeout time.Duration) {
+	t := time.NewTicker(timeout / 2)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			p.unsubscribeIdle(now.Add(-timeout))
+		}
+	}
+}
+
+// unsubscribeIdle unsubscribes from the currencies last requested before the
+// given time and removes their cached rates
+func (p *ProductsDB) unsubscribeIdle(before time.Time) {
+	p.mu.Lock()
+	client := p.client
+	idle := []string{}
+	for dest, used := range p.lastUsed {
+		if !used.Before(before) {
+			continue
+		}
+
+		p.log.Info("Unsubscribing from idle currency", "dest", dest, "last_used", used)
+
+		delete(p.rates, dest)
+		delete(p.lastUsed, dest)
+		delete(p.subscribed, dest)
+		idle = append(idle, dest)
+	}
+	p.mu.Unlock()
+
+	if client == nil {
+		return
+	}
+
+	for _, dest := range idle {
+		err := p.send(client, &protos.SubscribeRatesRequest{
+			Message: &protos.SubscribeRatesRequest_Unsubscribe{Unsubscribe: rateRequest(dest)},
+		})
+		if err != nil {
+			p.log.Error("Unable to unsubscribe from rate", "dest", dest, "error", err)
+		}
+	}
+}
+
+// getRateAt returns the rate for the destination currency on the given date
+// or the latest rate when asOf is zero. An ErrUnknownCurrency error is
+// returned when the destination is not an ISO 4217 code
+func (p *ProductsDB) getRateAt(ctx context.Context, destination string, asOf time.Time) (Rate, error) {
+	// reject unknown codes before they reach the cache or the currency service
+	if !registry.Valid(destination) {
+		return Rate{}, ErrUnknownCurrency
+	}
+
+	// prices are stored in the base currency, the currency service does not
+	// convert a currency to itself
+	if destination == BaseCurrency {
+		return Rate{Value: 1, Updated: time.Now()}, nil
+	}
+
+	if asOf.IsZero() {
+		return p.getRate(ctx, destination)
+	}
+
+	return p.getHistoricalRate(ctx, destination, asOf)
+}
+
+// getHistoricalRate returns the rate for the destination currency published
+// on, or most recently before, the given date
+func (p *ProductsDB) getHistoricalRate(ctx context.Context, destination string, asOf time.Time) (Rate, error) {
+	date := asOf.Format("2006-01-02")
+	key := destination + "@" + date
+
+	// If cached, return
+	p.mu.RLock()
+	r, ok := p.historical[key]
+	p.mu.RUnlock()
+	if ok {
+		return r, nil
+	}
+
+	hr := &protos.HistoricalRateRequest{
+		Base:        BaseCurrency,
+		Destination: destination,
+		Date:        date,
+	}
+
+	res, err := p.currency.GetHistoricalRate(ctx, hr)
+	if err != nil {
+		if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+			return Rate{}, ErrHistoricalRateNotFound
+		}
+		if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+			requestlog.Logger(ctx, p.log).Error("Currency server rejected historical rate request", "dest", destination, "date", date, "error", s.Message())
+			return Rate{}, ErrInvalidRateRequest
+		}
+
+		return Rate{}, fmt.Errorf("Unable to get historical rate from currency server, dest: %s, date: %s: %w", destination, date, err)
+	}
+
+	published, err := time.Parse("2006-01-02", res.GetDate())
+	if err != nil {
+		return Rate{}, fmt.Errorf("Invalid date from currency server, dest: %s, date: %s: %w", destination, res.GetDate(), err)
+	}
+
+	r = Rate{Value: res.GetRate(), Updated: published}
+
+	// the server falls back to the last rates published before the date, they
+	// are only final once rates were published on the date and it has passed
+	today := time.Now().UTC().Format("2006-01-02")
+	if res.GetDate() == date && date < today {
+		p.mu.Lock()
+		p.historical[key] = r
+		p.mu.Unlock()
+	}
+
+	return r, nil
+}
+
+// freshLocked returns true when the cached rate for the destination can be
+// used without fetching it again. The stream sends a rate only when it
+// changes so a rate subscribed to and cached since the stream connected is
+// current however old it is, otherwise the rate is used until it is older
+// than RateTTL. p.mu must be held
+func (p *ProductsDB) freshLocked(destination string, r Rate) bool {
+	if p.client != nil && p.subscribed[destination] && !r.Updated.Before(p.connected) {
+		return true
+	}
+
+	return time.Since(r.Updated) < RateTTL
+}
+
+// getRate returns the latest rate for the destination currency. Cached rates
+// are used while they are fresh, after which the rate is fetched again from
+// the currency service
+func (p *ProductsDB) getRate(ctx context.Context, destination string) (Rate, error) {
+
+	// If cached and fresh, return
+	p.mu.Lock()
+	r, ok := p.rates[destination]
+	fresh := ok && p.freshLocked(destination, r)
+	if ok {
+		p.lastUsed[destination] = time.Now()
+	}
+	p.mu.Unlock()
+	if fresh {
+		rateCacheRequests.WithLabelValues("hit").Inc()
+		return r, nil
+	}
+	if ok {
+		rateCacheRequests.WithLabelValues("stale").Inc()
+		requestlog.Logger(ctx, p.log).Debug("Cached rate is stale, fetching from currency server", "dest", destination, "updated", r.Updated)
+	} else {
+		rateCacheRequests.WithLabelValues("miss").Inc()
+	}
+
+	// Construct request with base "EUR" to destination specificed
+	rr := &protos.RatesRequest{
+		Base:         BaseCurrency,
+		Destinations: []string{destination},
+	}
+
+	res, err := p.currency.GetRates(ctx, rr)
+	if err != nil {
+		if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+			return Rate{}, ErrRateNotFound
+		}
+		if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+			requestlog.Logger(ctx, p.log).Error("Currency server rejected rate request", "base", rr.GetBase(), "dest", destination, "error", s.Message())
+			return Rate{}, ErrInvalidRateRequest
+		}
+
+		return Rate{}, fmt.Errorf("Unable to get rate from currency server, base: %s, dest: %s: %w", rr.GetBase(), destination, err)
+	}
+	if len(res.GetRates()) != 1 {
+		return Rate{}, fmt.Errorf("Unable to get rate from currency server, base: %s, dest: %s: expected 1 rate got %d", rr.GetBase(), destination, len(res.GetRates()))
+	}
+
+	r = Rate{Value: res.GetRates()[0].GetRate(), Updated: time.Now(), SnapshotID: res.GetSnapshotID()}
+
+	p.mu.Lock()
+	p.rates[destination] = r // update cache
+	p.lastUsed[destination] = r.Updated
+	subscribed := p.subscribed[destination]
+	p.mu.Unlock()
+
+	// subscribe for updates unless already subscribed, a failed subscription
+	// is tried again the next time the rate is fetched
+	if !subscribed {
+		p.subscribe(destination)
+	}
+
+	return r, nil
+}
+
+// rateRequest returns a request for the rate from EUR to the destination
+func rateRequest(destination string) *protos.RateRequest {
+	return &protos.RateRequest{
+		Base:        BaseCurrency,
+		Destination: destination,
+	}
+}
@@ -0,0 +1,435 @@
+package data
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCurrencyClient returns a fixed rate, the given currencies and the given
+// rate stream, the context of the last rate request is kept. Historical rates
+// are published on the published date or on the requested date when empty
+type fakeCurrencyClient struct {
+	protos.CurrencyClient
+	rate       float64
+	calls      int
+	ctx        context.Context
+	currencies []*protos.CurrencyInfo
+	stream     *fakeRateStream
+	published  string
+}
+
+func (f *fakeCurrencyClient) GetRates(ctx context.Context, rr *protos.RatesRequest, opts ...grpc.CallOption) (*protos.RatesResponse, error) {
+	f.calls++
+	f.ctx = ctx
+
+	res := &protos.RatesResponse{Base: rr.GetBase(), SnapshotID: "test"}
+	for _, d := range rr.GetDestinations() {
+		res.Rates = append(res.Rates, &protos.RateResponse{Base: rr.GetBase(), Destination: d, Rate: f.rate, SnapshotID: "test"})
+	}
+
+	return res, nil
+}
+
+func (f *fakeCurrencyClient) GetHistoricalRate(ctx context.Context, hr *protos.HistoricalRateRequest, opts ...grpc.CallOption) (*protos.HistoricalRateResponse, error) {
+	f.calls++
+
+	published := f.published
+	if published == "" {
+		published = hr.GetDate()
+	}
+
+	return &protos.HistoricalRateResponse{Rate: f.rate, Date: published}, nil
+}
+
+func (f *fakeCurrencyClient) ListCurrencies(ctx context.Context, lr *protos.ListCurrenciesRequest, opts ...grpc.CallOption) (*protos.ListCurrenciesResponse, error) {
+	return &protos.ListCurrenciesResponse{Currencies: f.currencies}, nil
+}
+
+func (f *fakeCurrencyClient) SubscribeRates(ctx context.Context, opts ...grpc.CallOption) (protos.Currency_SubscribeRatesClient, error) {
+	return f.stream, nil
+}
+
+// fakeRateStream records the messages sent on the rate stream, or fails the
+// sends with sendErr when set. When block is set sends are announced on
+// sending and wait for block to be closed as if held back by flow control.
+// Recv returns io.EOF as if the server closed the stream
+type fakeRateStream struct {
+	grpc.ClientStream
+	sent    []*protos.SubscribeRatesRequest
+	sendErr error
+	sending chan struct{}
+	block   chan struct{}
+}
+
+func (f *fakeRateStream) Send(req *protos.SubscribeRatesRequest) error {
+	if f.block != nil {
+		f.sending <- struct{}{}
+		<-f.block
+	}
+
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+
+	f.sent = append(f.sent, req)
+	return nil
+}
+
+func (f *fakeRateStream) Recv() (*protos.SubscribeRatesResponse, error) {
+	return nil, io.EOF
+}
+
+func TestUnsubscribeIdleCurrencies(t *testing.T) {
+	now := time.Now()
+	fs := &fakeRateStream{}
+	p := &ProductsDB{
+		log:    hclog.NewNullLogger(),
+		client: fs,
+		rates:  map[string]Rate{"USD": {Value: 1.18, Updated: now}, "GBP": {Value: 0.86, Updated: now}},
+		lastUsed: map[string]time.Time{
+			"USD": now,
+			"GBP": now.Add(-time.Hour),
+		},
+	}
+
+	p.unsubscribeIdle(now.Add(-SubscriptionIdleTimeout))
+
+	assert.Contains(t, p.rates, "USD")
+	assert.NotContains(t, p.rates, "GBP")
+	assert.NotContains(t, p.lastUsed, "GBP")
+
+	if assert.Len(t, fs.sent, 1) {
+		assert.Equal(t, "GBP", fs.sent[0].GetUnsubscribe().GetDestination())
+	}
+}
+
+func TestStreamErrorRemovesCachedRate(t *testing.T) {
+	now := time.Now()
+	p := &ProductsDB{
+		log:      hclog.NewNullLogger(),
+		rates:    map[string]Rate{"USD": {Value: 1.18, Updated: now}, "GBP": {Value: 0.86, Updated: now}},
+		lastUsed: map[string]time.Time{"USD": now, "GBP": now},
+	}
+
+	s, err := status.New(codes.NotFound, "Rate not found for GBP").WithDetails(rateRequest("GBP"))
+	assert.NoError(t, err)
+
+	p.handleStreamError(s)
+
+	assert.Contains(t, p.rates, "USD")
+	assert.NotContains(t, p.rates, "GBP")
+	assert.NotContains(t, p.lastUsed, "GBP")
+}
+
+func TestGetRateFetchesStaleRates(t *testing.T) {
+	fc := &fakeCurrencyClient{rate: 1.2}
+	p := &ProductsDB{
+		currency: fc,
+		log:      hclog.NewNullLogger(),
+		rates: map[string]Rate{
+			"USD": {Value: 1.18, Updated: time.Now()},
+			"GBP": {Value: 0.86, Updated: time.Now().Add(-2 * RateTTL)},
+		},
+		lastUsed: map[string]time.Time{},
+	}
+
+	hits := testutil.ToFloat64(rateCacheRequests.WithLabelValues("hit"))
+	stale := testutil.ToFloat64(rateCacheRequests.WithLabelValues("stale"))
+
+	// fresh rates come from the cache
+	r, err := p.getRate(context.Background(), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.18, r.Value)
+	assert.Equal(t, 0, fc.calls)
+	assert.Equal(t, hits+1, testutil.ToFloat64(rateCacheRequests.WithLabelValues("hit")))
+
+	// stale rates are fetched again and cached
+	r, err = p.getRate(context.Background(), "GBP")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.2, r.Value)
+	assert.Equal(t, "test", r.SnapshotID)
+	assert.Equal(t, 1, fc.calls)
+	assert.WithinDuration(t, time.Now(), p.rates["GBP"].Updated, time.Second)
+	assert.Equal(t, stale+1, testutil.ToFloat64(rateCacheRequests.WithLabelValues("stale")))
+}
+
+func TestGetRateUsesRatesKeptByIdleStream(t *testing.T) {
+	connected := time.Now().Add(-time.Hour)
+	fc := &fakeCurrencyClient{rate: 1.2}
+	p := &ProductsDB{
+		currency:  fc,
+		log:       hclog.NewNullLogger(),
+		client:    &fakeRateStream{},
+		connected: connected,
+		rates: map[string]Rate{
+			// no update has been streamed since the rate was cached
+			"USD": {Value: 1.18, Updated: connected.Add(time.Minute)},
+			// cached before the stream connected so it may have missed updates
+			"GBP": {Value: 0.86, Updated: connected.Add(-time.Minute)},
+		},
+		lastUsed:   map[string]time.Time{},
+		subscribed: map[string]bool{"USD": true, "GBP": true},
+	}
+
+	for i := 0; i < 3; i++ {
+		r, err := p.getRate(context.Background(), "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.18, r.Value)
+	}
+	assert.Equal(t, 0, fc.calls)
+
+	// rates from before the stream connected are fetched once
+	for i := 0; i < 3; i++ {
+		r, err := p.getRate(context.Background(), "GBP")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.2, r.Value)
+	}
+	assert.Equal(t, 1, fc.calls)
+
+	// the TTL applies again once the stream is down
+	p.client = nil
+	_, err := p.getRate(context.Background(), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fc.calls)
+}
+
+func TestGetHistoricalRateOnlyCachesFinalRates(t *testing.T) {
+	past := time.Date(2020, time.August, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		asOf      time.Time
+		published string
+		calls     int
+	}{
+		{"published on a past date", past, "", 1},
+		{"published before the date", past, "2020-07-31", 2},
+		{"today", time.Now().UTC(), "", 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := &fakeCurrencyClient{rate: 1.2, published: tc.published}
+			p := &ProductsDB{currency: fc, log: hclog.NewNullLogger(), historical: map[string]Rate{}}
+
+			for i := 0; i < 2; i++ {
+				r, err := p.getHistoricalRate(context.Background(), "USD", tc.asOf)
+				assert.NoError(t, err)
+				assert.Equal(t, 1.2, r.Value)
+			}
+
+			assert.Equal(t, tc.calls, fc.calls)
+		})
+	}
+}
+
+func TestGetRateAppliesTTLUntilSubscribed(t *testing.T) {
+	connected := time.Now().Add(-time.Hour)
+	fs := &fakeRateStream{sendErr: io.ErrClosedPipe}
+	fc := &fakeCurrencyClient{rate: 1.2}
+	p := &ProductsDB{
+		currency:   fc,
+		log:        hclog.NewNullLogger(),
+		client:     fs,
+		connected:  connected,
+		rates:      map[string]Rate{},
+		lastUsed:   map[string]time.Time{},
+		subscribed: map[string]bool{},
+	}
+
+	// expire the cached rate while keeping it newer than the stream
+	expire := func() {
+		r := p.rates["USD"]
+		r.Updated = time.Now().Add(-2 * RateTTL)
+		p.rates["USD"] = r
+	}
+
+	_, err := p.getRate(context.Background(), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fc.calls)
+	assert.False(t, p.subscribed["USD"])
+
+	// the subscription was not sent so the rate is not kept fresh by the stream
+	expire()
+	_, err = p.getRate(context.Background(), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fc.calls)
+
+	// the subscription is tried again when the rate is fetched
+	fs.sendErr = nil
+	expire()
+	_, err = p.getRate(context.Background(), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fc.calls)
+	assert.True(t, p.subscribed["USD"])
+
+	expire()
+	_, err = p.getRate(context.Background(), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fc.calls)
+}
+
+func TestBlockedSendDoesNotBlockCachedRates(t *testing.T) {
+	fs := &fakeRateStream{sending: make(chan struct{}, 1), block: make(chan struct{})}
+	fc := &fakeCurrencyClient{rate: 1.2}
+	p := &ProductsDB{
+		currency:   fc,
+		log:        hclog.NewNullLogger(),
+		client:     fs,
+		rates:      map[string]Rate{"USD": {Value: 1.18, Updated: time.Now()}},
+		lastUsed:   map[string]time.Time{},
+		subscribed: map[string]bool{},
+	}
+
+	// fetching GBP blocks sending its subscription
+	fetched := make(chan struct{})
+	go func() {
+		defer close(fetched)
+		_, err := p.getRate(context.Background(), "GBP")
+		assert.NoError(t, err)
+	}()
+	<-fs.sending
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, p.CheckRateStream())
+
+		r, err := p.getRate(context.Background(), "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.18, r.Value)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cached rate blocked by a send on the rate stream")
+	}
+
+	close(fs.block)
+	<-fetched
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	assert.True(t, p.subscribed["GBP"])
+}
+
+func TestGetRateRejectsUnknownCurrency(t *testing.T) {
+	fc := &fakeCurrencyClient{rate: 1.2}
+	p := &ProductsDB{
+		currency:   fc,
+		log:        hclog.NewNullLogger(),
+		rates:      map[string]Rate{},
+		historical: map[string]Rate{},
+		lastUsed:   map[string]time.Time{},
+	}
+
+	for _, c := range []string{"XYZ", "usd"} {
+		_, err := p.getRateAt(context.Background(), c, time.Time{})
+		assert.Equal(t, ErrUnknownCurrency, err)
+	}
+
+	assert.Equal(t, 0, fc.calls)
+	assert.Empty(t, p.rates)
+}
+
+func TestStreamRatesResubscribesCachedRates(t *testing.T) {
+	fs := &fakeRateStream{}
+	p := &ProductsDB{
+		currency: &fakeCurrencyClient{stream: fs},
+		log:      hclog.NewNullLogger(),
+		rates:    map[string]Rate{"USD": {Value: 1.18, Updated: time.Now()}},
+		lastUsed: map[string]time.Time{},
+	}
+
+	received, err := p.streamRates(context.Background())
+	assert.Equal(t, io.EOF, err)
+	assert.False(t, received)
+	assert.WithinDuration(t, time.Now(), p.connected, time.Second)
+
+	if assert.Len(t, fs.sent, 1) {
+		assert.Equal(t, "USD", fs.sent[0].GetSubscribe().GetDestination())
+	}
+
+	// sends are not attempted on a closed stream
+	assert.Nil(t, p.client)
+}
+
+func TestCloseStopsBackgroundGoroutines(t *testing.T) {
+	p := NewProductsDB(&fakeCurrencyClient{stream: &fakeRateStream{}}, hclog.NewNullLogger(), NewMemoryStore())
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not stop the background goroutines")
+	}
+
+	assert.Nil(t, p.client)
+}
+
+func TestNextReconnectDelay(t *testing.T) {
+	assert.Equal(t, 2*time.Second, nextReconnectDelay(time.Second))
+	assert.Equal(t, maxReconnectDelay, nextReconnectDelay(maxReconnectDelay))
+	assert.Equal(t, maxReconnectDelay, nextReconnectDelay(maxReconnectDelay/2+time.Second))
+}
+
+func TestCheckRateStream(t *testing.T) {
+	p := &ProductsDB{log: hclog.NewNullLogger()}
+	assert.Equal(t, ErrRateStreamDisconnected, p.CheckRateStream())
+
+	p.client = &fakeRateStream{}
+	assert.NoError(t, p.CheckRateStream())
+}
+
+// rejectingCurrencyClient rejects every rate request as invalid
+type rejectingCurrencyClient struct {
+	protos.CurrencyClient
+}
+
+func (rejectingCurrencyClient) GetRates(ctx context.Context, rr *protos.RatesRequest, opts ...grpc.CallOption) (*protos.RatesResponse, error) {
+	return nil, status.Error(codes.InvalidArgument, "invalid request")
+}
+
+func (rejectingCurrencyClient) GetHistoricalRate(ctx context.Context, hr *protos.HistoricalRateRequest, opts ...grpc.CallOption) (*protos.HistoricalRateResponse, error) {
+	return nil, status.Error(codes.InvalidArgument, "invalid request")
+}
+
+func TestGetRateAtBaseCurrency(t *testing.T) {
+	p := &ProductsDB{
+		currency:   rejectingCurrencyClient{},
+		log:        hclog.NewNullLogger(),
+		rates:      map[string]Rate{},
+		lastUsed:   map[string]time.Time{},
+		historical: map[string]Rate{},
+	}
+
+	// the base currency is never requested from the currency service
+	for _, asOf := range []time.Time{{}, time.Date(2020, time.August, 3, 0, 0, 0, 0, time.UTC)} {
+		r, err := p.getRateAt(context.Background(), BaseCurrency, asOf)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, r.Value)
+	}
+
+	// other rejected requests are reported as invalid
+	_, err := p.getRateAt(context.Background(), "USD", time.Time{})
+	assert.Equal(t, ErrInvalidRateRequest, err)
+
+	_, err = p.getRateAt(context.Background(), "USD", time.Date(2020, time.August, 3, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, ErrInvalidRateRequest, err)
+}
@@ -0,0 +1,260 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrEmptySearch is an error raised when a search query contains no terms
+var ErrEmptySearch = fmt.Errorf("Search query must contain at least one letter or number")
+
+// weights applied to each kind of match, an exact match on a term always
+// ranks above a prefix match which ranks above a typo
+const (
+	exactMatchWeight  = 1.0
+	prefixMatchWeight = 0.6
+	fuzzyMatchWeight  = 0.4
+
+	// terms in the name count more than those in the description
+	nameFieldWeight        = 2.0
+	descriptionFieldWeight = 1.0
+)
+
+// SearchResult is a product matching a search query with its relevance score
+// swagger:model
+type SearchResult struct {
+	// relevance of the product to the query, higher is more relevant
+	Score float64 `json:"score"`
+
+	// the matching product
+	Product *Product `json:"product"`
+}
+
+// searchHit is a product id and score returned by the index
+type searchHit struct {
+	id    int
+	score float64
+}
+
+// posting records how many times a term occurs in each field of a product
+type posting struct {
+	name        int
+	description int
+}
+
+// searchIndex is an in-process inverted index over the name and description
+// of products. searchIndex is safe for concurrent use
+type searchIndex struct {
+	mu    sync.RWMutex
+	terms map[string]map[int]*posting
+	docs  map[int][]string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		terms: map[string]map[int]*posting{},
+		docs:  map[int][]string{},
+	}
+}
+
+// add indexes the product, replacing any previous entry with the same id
+func (s *searchIndex) add(p *Product) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(p.ID)
+
+	postings := map[string]*posting{}
+	for _, t := range tokenize(p.Name) {
+		if postings[t] == nil {
+			postings[t] = &posting{}
+		}
+		postings[t].name++
+	}
+	for _, t := range tokenize(p.Description) {
+		if postings[t] == nil {
+			postings[t] = &posting{}
+		}
+		postings[t].description++
+	}
+
+	terms := []string{}
+	for t, ps := range postings {
+		if s.terms[t] == nil {
+			s.terms[t] = map[int]*posting{}
+		}
+		s.terms[t][p.ID] = ps
+		terms = append(terms, t)
+	}
+	s.docs[p.ID] = terms
+}
+
+// remove deletes the product with the given id from the index
+func (s *searchIndex) remove(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(id)
+}
+
+func (s *searchIndex) removeLocked(id int) {
+	for _, t := range s.docs[id] {
+		delete(s.terms[t], id)
+		if len(s.terms[t]) == 0 {
+			delete(s.terms, t)
+		}
+	}
+	delete(s.docs, id)
+}
+
+// search returns the ids of the products matching any term in the query
+// ordered by relevance, most relevant first
+func (s *searchIndex) search(query string) ([]searchHit, error) {
+	qts := tokenize(query)
+	if len(qts) == 0 {
+		return nil, ErrEmptySearch
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := float64(len(s.docs))
+	scores := map[int]float64{}
+
+	for _, qt := range qts {
+		// each product scores only its best match for every query term so a
+		// typo can not outrank the exact word it is close to
+		best := map[int]float64{}
+
+		for t, postings := range s.terms {
+			w := matchWeight(qt, t)
+			if w == 0 {
+				continue
+			}
+
+			// rarer terms are more significant
+			idf := math.Log(1 + n/float64(len(postings)))
+
+			for id, p := range postings {
+				tf := nameFieldWeight*float64(p.name) + descriptionFieldWeight*float64(p.description)
+				score := w * idf * tf
+				if score > best[id] {
+					best[id] = score
+				}
+			}
+		}
+
+		for id, score := range best {
+			scores[id] += score
+		}
+	}
+
+	hits := []searchHit{}
+	for id, score := range scores {
+		hits = append(hits, searchHit{id, score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].id < hits[j].id
+	})
+
+	return hits, nil
+}
+
+// matchWeight returns how well the query term matches an indexed term
+// returns 0 when the terms do not match
+func matchWeight(query, term string) float64 {
+	if query == term {
+		return exactMatchWeight
+	}
+
+	// prefix matching needs a couple of characters to be useful
+	if len([]rune(query)) >= 2 && strings.HasPrefix(term, query) {
+		return prefixMatchWeight
+	}
+
+	max := maxEdits(query)
+	if max > 0 && editDistance(query, term, max) <= max {
+		return fuzzyMatchWeight
+	}
+
+	return 0
+}
+
+// maxEdits returns the number of typos tolerated for a query term, short
+// terms must match exactly otherwise almost everything would match
+func maxEdits(term string) int {
+	switch l := len([]rune(term)); {
+	case l < 4:
+		return 0
+	case l < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the Levenshtein distance between a and b, once the
+// distance is known to be greater than max, max+1 is returned
+func editDistance(a, b string, max int) int {
+	ar, br := []rune(a), []rune(b)
+
+	d := len(ar) - len(br)
+	if d < 0 {
+		d = -d
+	}
+	if d > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+			if cur[j] < rowMin {
+				rowMin = cur[j]
+			}
+		}
+
+		if rowMin > max {
+			return max + 1
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(br)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// tokenize splits text into lower case terms of letters and numbers
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
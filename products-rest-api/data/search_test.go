@@ -0,0 +1,149 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchIndexWith(ps ...*Product) *searchIndex {
+	si := newSearchIndex()
+	for _, p := range ps {
+		si.add(p)
+	}
+
+	return si
+}
+
+func hitIDs(hits []searchHit) []int {
+	ids := []int{}
+	for _, h := range hits {
+		ids = append(ids, h.id)
+	}
+
+	return ids
+}
+
+func TestSearchRanksNameAboveDescription(t *testing.T) {
+	si := searchIndexWith(
+		&Product{ID: 1, Name: "Latte", Description: "Frothy milky coffee"},
+		&Product{ID: 2, Name: "Esspresso", Description: "Short and strong, not a latte"},
+		&Product{ID: 3, Name: "Tea", Description: "Leaves in hot water"},
+	)
+
+	hits, err := si.search("latte")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, hitIDs(hits))
+	assert.True(t, hits[0].score > hits[1].score)
+}
+
+func TestSearchMatchesPrefixes(t *testing.T) {
+	si := searchIndexWith(
+		&Product{ID: 1, Name: "Cappuccino"},
+		&Product{ID: 2, Name: "Latte"},
+	)
+
+	hits, err := si.search("capp")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, hitIDs(hits))
+}
+
+func TestSearchToleratesTypos(t *testing.T) {
+	si := searchIndexWith(
+		&Product{ID: 1, Name: "Esspresso"},
+		&Product{ID: 2, Name: "Mocha"},
+	)
+
+	hits, err := si.search("espresso")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, hitIDs(hits))
+
+	// short terms must match exactly
+	hits, err = si.search("tea")
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestSearchExactRanksAboveFuzzy(t *testing.T) {
+	si := searchIndexWith(
+		&Product{ID: 1, Name: "Mochas"},
+		&Product{ID: 2, Name: "Mocha"},
+	)
+
+	hits, err := si.search("mocha")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, hitIDs(hits))
+}
+
+func TestSearchIndexFollowsUpdatesAndDeletes(t *testing.T) {
+	si := searchIndexWith(&Product{ID: 1, Name: "Latte"})
+
+	si.add(&Product{ID: 1, Name: "Flat White"})
+	hits, err := si.search("latte")
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = si.search("white")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, hitIDs(hits))
+
+	si.remove(1)
+	hits, err = si.search("white")
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+	assert.Empty(t, si.terms)
+}
+
+// slowStore pauses after updating a product, widening the window for another
+// update to overtake it before the index is updated
+type slowStore struct {
+	ProductStore
+}
+
+func (s slowStore) Update(p *Product) error {
+	err := s.ProductStore.Update(p)
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	return err
+}
+
+func TestConcurrentUpdatesLeaveIndexMatchingStore(t *testing.T) {
+	p := &ProductsDB{log: hclog.NewNullLogger(), store: slowStore{NewMemoryStore()}, index: newSearchIndex()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := p.UpdateProduct(context.Background(), &Product{ID: 1, Name: fmt.Sprintf("Latte%d", i)})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := p.store.Get(1)
+	assert.NoError(t, err)
+
+	// the index holds the terms of the stored name only
+	assert.ElementsMatch(t, tokenize(stored.Name+" "+stored.Description), p.index.docs[1])
+}
+
+func TestSearchEmptyQueryReturnsErr(t *testing.T) {
+	si := searchIndexWith(&Product{ID: 1, Name: "Latte"})
+
+	_, err := si.search(" ,. ")
+	assert.Equal(t, ErrEmptySearch, err)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("latte", "latte", 2))
+	assert.Equal(t, 1, editDistance("latte", "late", 2))
+	assert.Equal(t, 2, editDistance("flat", "flta", 2))
+	assert.Equal(t, 3, editDistance("latte", "mocha", 2))
+}
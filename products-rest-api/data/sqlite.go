@@ -0,0 +1,164 @@
+package data
+
+import (
+	"database/sql"
+	"fmt"
+
+	// register the sqlite3 driver with database/sql
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteStore is an implementation of the ProductStore interface which
+// persists products in an embedded SQLite database
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens, or creates, the SQLite database at the given path and
+// applies any outstanding schema migrations
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open database: %w", err)
+	}
+
+	// SQLite only allows a single writer, sharing one connection avoids
+	// "database is locked" errors and keeps :memory: databases intact
+	db.SetMaxOpenConns(1)
+
+	err = migrate(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteStore{db}, nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// List returns all products in the database ordered by id
+func (s *SQLiteStore) List() (Products, error) {
+	rows, err := s.db.Query(`SELECT id, name, description, price, currency, sku, version FROM products ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to query products: %w", err)
+	}
+	defer rows.Close()
+
+	ps := Products{}
+	for rows.Next() {
+		p := &Product{}
+		err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price.Amount, &p.Price.Currency, &p.SKU, &p.Version)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to scan product: %w", err)
+		}
+		p.Price = NewMoney(p.Price.Amount, p.Price.Currency)
+		ps = append(ps, p)
+	}
+
+	return ps, rows.Err()
+}
+
+// Get returns the product with the given id
+func (s *SQLiteStore) Get(id int) (*Product, error) {
+	p := &Product{}
+	err := s.db.QueryRow(
+		`SELECT id, name, description, price, currency, sku, version FROM products WHERE id = ?`, id,
+	).Scan(&p.ID, &p.Name, &p.Description, &p.Price.Amount, &p.Price.Currency, &p.SKU, &p.Version)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to query product: %w", err)
+	}
+	p.Price = NewMoney(p.Price.Amount, p.Price.Currency)
+
+	return p, nil
+}
+
+// Add inserts the product and sets its ID to the one generated by the database
+func (s *SQLiteStore) Add(p *Product) error {
+	res, err := s.db.Exec(
+		`INSERT INTO products (name, description, price, currency, sku, version) VALUES (?, ?, ?, ?, ?, 1)`,
+		p.Name, p.Description, p.Price.Amount, p.Price.Currency, p.SKU,
+	)
+	if err != nil {
+		return fmt.Errorf("Unable to insert product: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	p.ID = int(id)
+	p.Version = 1
+
+	return nil
+}
+
+// Update replaces the product with the same id as the given item
+func (s *SQLiteStore) Update(p *Product) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current int
+	err = tx.QueryRow(`SELECT version FROM products WHERE id = ?`, p.ID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to query product version: %w", err)
+	}
+
+	if p.Version != 0 && p.Version != current {
+		return ErrVersionConflict
+	}
+
+	_, err = tx.Exec(
+		`UPDATE products SET name = ?, description = ?, price = ?, currency = ?, sku = ?, version = ? WHERE id = ?`,
+		p.Name, p.Description, p.Price.Amount, p.Price.Currency, p.SKU, current+1, p.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("Unable to update product: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	p.Version = current + 1
+
+	return nil
+}
+
+// Delete removes the product with the given id
+func (s *SQLiteStore) Delete(id int) error {
+	res, err := s.db.Exec(`DELETE FROM products WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("Unable to delete product: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// checkAffected returns a ProductNotFound error when a statement did not
+// change any rows
+func checkAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrProductNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package data
+
+// ProductStore defines the behavior for persisting products
+// Implementations may be an in memory list, an embedded SQL database, etc
+// and must be safe for concurrent use
+type ProductStore interface {
+	// List returns all products ordered by id
+	List() (Products, error)
+
+	// Get returns the product with the given id
+	// If a product is not found this returns a ProductNotFound error
+	Get(id int) (*Product, error)
+
+	// Add inserts a new product, setting its ID to the one assigned by the store
+	// and its Version to 1
+	Add(p *Product) error
+
+	// Update replaces the product which has the same id as the given item
+	// If a product is not found this returns a ProductNotFound error.
+	// When p.Version is not zero it must equal the stored version, otherwise
+	// a VersionConflict error is returned. On success p.Version is set to the
+	// incremented version
+	Update(p *Product) error
+
+	// Delete removes the product with the given id
+	// If a product is not found this returns a ProductNotFound error
+	Delete(id int) error
+}
@@ -0,0 +1,26 @@
+package data
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+)
+
+// tracer records the spans for the product store operations, it does nothing
+// until a trace provider is installed
+var tracer = global.Tracer("github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data")
+
+// traceStore runs the store operation op in a child span of the request,
+// a failed operation is recorded as an error on the span
+func traceStore(ctx context.Context, op string, fn func() error) error {
+	ctx, span := tracer.Start(ctx, "ProductStore."+op, trace.WithAttributes(label.String("db.operation", op)))
+	defer span.End()
+
+	err := fn()
+	span.RecordError(ctx, err, trace.WithErrorStatus(codes.Unknown))
+
+	return err
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// spanRecorder keeps the spans which have ended
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []*export.SpanData
+}
+
+func (s *spanRecorder) ExportSpan(ctx context.Context, sd *export.SpanData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spans = append(s.spans, sd)
+}
+
+func (s *spanRecorder) named(name string) *export.SpanData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sd := range s.spans {
+		if sd.Name == name {
+			return sd
+		}
+	}
+
+	return nil
+}
+
+func TestGetProductsTracesStoreAndRateRequest(t *testing.T) {
+	sr := &spanRecorder{}
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithSyncer(sr),
+	)
+	assert.NoError(t, err)
+	global.SetTraceProvider(tp)
+
+	fc := &fakeCurrencyClient{rate: 2}
+	p := &ProductsDB{
+		currency: fc,
+		log:      hclog.NewNullLogger(),
+		store:    NewMemoryStore(),
+		rates:    map[string]Rate{},
+		lastUsed: map[string]time.Time{},
+	}
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+	_, err = p.GetProducts(ctx, "USD", time.Time{}, nil)
+	span.End()
+	assert.NoError(t, err)
+
+	req := sr.named("request")
+	list := sr.named("ProductStore.List")
+	if assert.NotNil(t, list) {
+		assert.Equal(t, req.SpanContext.TraceID, list.SpanContext.TraceID)
+		assert.Equal(t, req.SpanContext.SpanID, list.ParentSpanID)
+	}
+
+	// the rate request is made with the context of the request
+	assert.Equal(t, req.SpanContext.SpanID, trace.SpanFromContext(fc.ctx).SpanContext().SpanID)
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 
 	"github.com/go-playground/validator"
@@ -44,6 +45,13 @@ type Validation struct {
 func NewValidation() *Validation {
 	validate := validator.New()
 	validate.RegisterValidation("sku", validateSKU)
+	validate.RegisterValidation("price", validatePrice)
+
+	// struct fields are not checked by field validators, validate Money in
+	// its string form instead
+	validate.RegisterCustomTypeFunc(func(v reflect.Value) interface{} {
+		return v.Interface().(Money).String()
+	}, Money{})
 
 	return &Validation{validate}
 }
@@ -88,16 +96,23 @@ func (v *Validation) Validate(i interface{}) ValidationErrors {
 	return returnErrs
 }
 
+// skuFormat is the format of a SKU, three groups of lower case letters
+// separated by dashes e.g. abc-abc-abc
+var skuFormat = regexp.MustCompile(`^[a-z]+-[a-z]+-[a-z]+$`)
+
 // validateSKU
 func validateSKU(fl validator.FieldLevel) bool {
-	// SKU must be in the format abc-abc-abc
-
-	re := regexp.MustCompile(`[a-z]`)
-	sku := re.FindAllString(fl.Field().String(), -1)
+	// SKU must be in the format abc-abc-abc and nothing else
+	return skuFormat.MatchString(fl.Field().String())
+}
 
-	if len(sku) == 1 {
-		return true
+// validatePrice
+func validatePrice(fl validator.FieldLevel) bool {
+	// price must be a positive amount in the base currency
+	m, err := ParseMoney(fl.Field().String())
+	if err != nil {
+		return false
 	}
 
-	return false
+	return m.Amount.IsPositive() && m.Currency == BaseCurrency
 }
@@ -3,16 +3,54 @@ module github.com/JamieBShaw/golang-mux-rest-api/products-rest-api
 go 1.14
 
 require (
+	github.com/JamieBShaw/golang-mux-rest-api/auth v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/config v0.0.0
 	github.com/JamieBShaw/golang-mux-rest-api/currency v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/health v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/metrics v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/ratelimit v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/requestlog v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/tracing v0.0.0
+	github.com/go-openapi/errors v0.19.6
 	github.com/go-openapi/runtime v0.19.20
+	github.com/go-openapi/strfmt v0.19.5
+	github.com/go-openapi/swag v0.19.9
+	github.com/go-openapi/validate v0.19.10
 	github.com/go-playground/universal-translator v0.17.0 // indirect
 	github.com/go-playground/validator v9.31.0+incompatible
+	github.com/google/go-cmp v0.5.1 // indirect
 	github.com/gorilla/handlers v1.4.2
-	github.com/gorilla/mux v1.7.4
+	github.com/gorilla/mux v1.8.0
 	github.com/hashicorp/go-hclog v0.14.1
 	github.com/leodido/go-urn v1.2.0 // indirect
-	google.golang.org/api v0.30.0
+	github.com/mattn/go-sqlite3 v1.14.0
+	github.com/prometheus/client_golang v1.7.1
+	github.com/shopspring/decimal v1.2.0
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux v0.11.0
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc v0.11.0
+	go.opentelemetry.io/otel v0.11.0
+	go.opentelemetry.io/otel/sdk v0.11.0
+	golang.org/x/net v0.0.0-20200707034311-ab3426394381 // indirect
+	golang.org/x/sys v0.0.0-20200803210538-64077c9b5642 // indirect
+	golang.org/x/text v0.3.3 // indirect
+	google.golang.org/genproto v0.0.0-20200804131852-c06518451d9c // indirect
 	google.golang.org/grpc v1.31.0
+	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 )
 
+replace github.com/JamieBShaw/golang-mux-rest-api/auth => ../auth
+
+replace github.com/JamieBShaw/golang-mux-rest-api/config => ../config
+
 replace github.com/JamieBShaw/golang-mux-rest-api/currency => ../currency
+
+replace github.com/JamieBShaw/golang-mux-rest-api/health => ../health
+
+replace github.com/JamieBShaw/golang-mux-rest-api/metrics => ../metrics
+
+replace github.com/JamieBShaw/golang-mux-rest-api/ratelimit => ../ratelimit
+
+replace github.com/JamieBShaw/golang-mux-rest-api/requestlog => ../requestlog
+
+replace github.com/JamieBShaw/golang-mux-rest-api/tracing => ../tracing
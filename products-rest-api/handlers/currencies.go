@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Currencies handler for listing the currencies prices can be converted to
+type Currencies struct {
+	l  hclog.Logger
+	db *data.ProductsDB
+}
+
+// NewCurrencies returns a new currencies handler with the given logger
+func NewCurrencies(l hclog.Logger, db *data.ProductsDB) *Currencies {
+	return &Currencies{l, db}
+}
+
+// logger returns the logger for the request, which logs the request ID
+func (c *Currencies) logger(r *http.Request) hclog.Logger {
+	return requestlog.Logger(r.Context(), c.l)
+}
+
+// swagger:route GET /currencies currencies listCurrencies
+// Returns the currencies which can be used to price products, ordered by code
+// responses:
+//  200: currenciesResponse
+//  429: tooManyRequestsResponse
+//  500: errorResponse
+
+// ListAll handles GET requests and returns the supported currencies
+func (c *Currencies) ListAll(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Add("Content-Type", "application/json")
+
+	cl, err := c.db.ListCurrencies(r.Context())
+	if err != nil {
+		c.logger(r).Error("Unable to list currencies", "error", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+
+	err = data.ToJSON(cl, rw)
+	if err != nil {
+		c.logger(r).Error("Unable to serialize currencies", "error", err)
+	}
+}
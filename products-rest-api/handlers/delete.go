@@ -7,11 +7,20 @@ import (
 )
 
 // swagger:route DELETE /products/{id} products deleteProduct
-// Update a products details
+// Delete a product
+//
+// Requires the products:write scope
+//
+// security:
+//	api_key:
+//	bearer:
 //
 // responses:
 //	201: noContentResponse
+//  401: errorResponse
+//  403: errorResponse
 //  404: errorResponse
+//  429: tooManyRequestsResponse
 //  501: errorResponse
 
 // Delete handles DELETE requests and removes items from the database
@@ -19,11 +28,11 @@ func (p *Products) Delete(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Add("Content-Type", "application/json")
 	id := getProductID(r)
 
-	p.l.Debug("Deleting record", "id", id)
+	p.logger(r).Debug("Deleting record", "id", id)
 
-	err := data.DeleteProduct(id)
+	err := p.db.DeleteProduct(r.Context(), id)
 	if err == data.ErrProductNotFound {
-		p.l.Error("Unable to find product to delete", "error", err)
+		p.logger(r).Error("Unable to find product to delete", "error", err)
 
 		rw.WriteHeader(http.StatusNotFound)
 		data.ToJSON(&GenericError{Message: err.Error()}, rw)
@@ -31,7 +40,7 @@ func (p *Products) Delete(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		p.l.Error("Unable to delte product", "error", err)
+		p.logger(r).Error("Unable to delete product", "error", err)
 
 		rw.WriteHeader(http.StatusInternalServerError)
 		data.ToJSON(&GenericError{Message: err.Error()}, rw)
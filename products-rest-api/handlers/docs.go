@@ -12,10 +12,24 @@
 //	Produces:
 //	- application/json
 //
+//	SecurityDefinitions:
+//	api_key:
+//	  type: apiKey
+//	  in: header
+//	  name: X-API-Key
+//	bearer:
+//	  type: apiKey
+//	  in: header
+//	  name: Authorization
+//	  description: a JWT in the format "Bearer {token}"
+//
 // swagger:meta
 package handlers
 
-import "github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
+import (
+	"github.com/JamieBShaw/golang-mux-rest-api/health"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
+)
 
 //
 // NOTE: Types defined here are purely for documentation purposes
@@ -29,6 +43,31 @@ type errorResponseWrapper struct {
 	Body GenericError
 }
 
+// The client has made too many requests, retry after the time in the
+// Retry-After header
+// swagger:response tooManyRequestsResponse
+type tooManyRequestsResponseWrapper struct {
+	// Number of requests the client can make in a burst
+	// in: header
+	RateLimitLimit int `json:"RateLimit-Limit"`
+
+	// Number of requests the client can make now
+	// in: header
+	RateLimitRemaining int `json:"RateLimit-Remaining"`
+
+	// Seconds until the client can make a full burst of requests again
+	// in: header
+	RateLimitReset int `json:"RateLimit-Reset"`
+
+	// Seconds until the client can make the next request
+	// in: header
+	RetryAfter int `json:"Retry-After"`
+
+	// Description of the error
+	// in: body
+	Body GenericError
+}
+
 // Validation errors defined as an array of strings
 // swagger:response errorValidation
 type errorValidationWrapper struct {
@@ -40,6 +79,25 @@ type errorValidationWrapper struct {
 // A list of products
 // swagger:response productsResponse
 type productsResponseWrapper struct {
+	// Link to the next page of products, only set when there are more results
+	// in: header
+	Link string
+
+	// Total number of products matching the query across all pages
+	// in: header
+	XTotalCount int `json:"X-Total-Count"`
+
+	// Time the exchange rate used to convert the prices was updated, only set
+	// when a currency is specified. For historical rates the date the rates
+	// were published
+	// in: header
+	XRateTimestamp string `json:"X-Rate-Timestamp"`
+
+	// Snapshot of the exchange rates on the currency service the rate was
+	// taken from, only set when a currency is specified and as_of is not
+	// in: header
+	XRateSnapshot string `json:"X-Rate-Snapshot"`
+
 	// All current products
 	// in: body
 	Body []data.Product
@@ -53,6 +111,53 @@ type productResponseWrapper struct {
 	Body data.Product
 }
 
+// Health of the service
+// swagger:response healthResponse
+type healthResponseWrapper struct {
+	// Status of the service and the reason each failing check is not ready
+	// in: body
+	Body health.Status
+}
+
+// swagger:route GET /healthz health liveness
+// Returns 200 while the service is running
+// responses:
+//  200: healthResponse
+
+// swagger:route GET /readyz health readiness
+// Returns 200 when the service can handle requests, otherwise 503 with the
+// reason each dependency is not ready
+// responses:
+//  200: healthResponse
+//  503: healthResponse
+
+// A list of currencies
+// swagger:response currenciesResponse
+type currenciesResponseWrapper struct {
+	// All currencies supported by the currency service
+	// in: body
+	Body []data.Currency
+}
+
+// Products matching a search ordered by relevance
+// swagger:response searchResponse
+type searchResponseWrapper struct {
+	// Time the exchange rate used to convert the prices was updated, only set
+	// when a currency is specified. For historical rates the date the rates
+	// were published
+	// in: header
+	XRateTimestamp string `json:"X-Rate-Timestamp"`
+
+	// Snapshot of the exchange rates on the currency service the rate was
+	// taken from, only set when a currency is specified and as_of is not
+	// in: header
+	XRateSnapshot string `json:"X-Rate-Snapshot"`
+
+	// Matching products and their scores
+	// in: body
+	Body []data.SearchResult
+}
+
 // No content is returned by this API endpoint
 // swagger:response noContentResponse
 type noContentResponseWrapper struct {
@@ -62,18 +167,89 @@ type noContentResponseWrapper struct {
 type productParamsWrapper struct {
 	// Product data structure to Update or Create.
 	// Note: the id field is ignored by update and create operations
+	// and the version field is ignored, use the If-Match header instead
 	// in: body
 	// required: true
 	Body data.Product
 }
 
-// swagger:parameters listProducts listSingleProduct
+// swagger:parameters updateProduct
+type productIfMatchParamWrapper struct {
+	// ETag of the product version the update is based on, as returned by
+	// GET /products/{id}. When the product has since been modified the
+	// update is rejected with 409 Conflict
+	// in: header
+	// required: false
+	IfMatch string `json:"If-Match"`
+}
+
+// swagger:parameters searchProducts
+type searchParamsWrapper struct {
+	// Words to search for in the product name and description
+	// in: query
+	// required: true
+	Q string `json:"q"`
+}
+
+// swagger:parameters listProducts listSingleProduct searchProducts
 type ProductQueryParam struct {
-	// Currency used when returning the price of the product.
-	// when not specified is returned in GBP
+	// ISO 4217 code of the currency used when returning the price of the
+	// product, e.g. USD. When not specified the price is returned in EUR
+	// in: query
+	// required: false
+	Currency string `json:"currency"`
+
+	// Date in the format YYYY-MM-DD used to price the products with the
+	// exchange rates published on that day, when not specified the latest
+	// rates are used. Only applies when a currency is specified
+	// in: query
+	// required: false
+	// swagger:strfmt date
+	AsOf string `json:"as_of"`
+}
+
+// swagger:parameters listProducts
+type productListParamsWrapper struct {
+	// Maximum number of products to return, at most 100.
+	// when not specified all products are returned
+	// in: query
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	Limit int `json:"limit"`
+
+	// Cursor for the page to return, taken from the Link header of the previous page
+	// in: query
+	// required: false
+	Cursor string `json:"cursor"`
+
+	// Field to sort the products by, prefix with - for descending order
+	// in: query
+	// required: false
+	// enum: id,-id,name,-name,price,-price
+	Sort string `json:"sort"`
+
+	// Only return products whose name contains this value, ignoring case
+	// in: query
+	// required: false
+	NameContains string `json:"name~"`
+
+	// Only return products with a price greater than or equal to this value,
+	// in the requested currency
+	// in: query
+	// required: false
+	MinPrice float64 `json:"min_price"`
+
+	// Only return products with a price less than or equal to this value,
+	// in the requested currency
+	// in: query
+	// required: false
+	MaxPrice float64 `json:"max_price"`
+
+	// Only return the product with this SKU
 	// in: query
 	// required: false
-	Currency string
+	SKU string `json:"sku"`
 }
 
 // swagger:parameters listSingleProduct deleteProduct
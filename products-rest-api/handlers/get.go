@@ -2,14 +2,19 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
 )
 
 // swagger:route GET /products products listProducts
-// Returns a list of products from the database
+// Returns a page of products from the database, filtered and sorted by the query
+// parameters. When more products are available the Link header contains the
+// URL of the next page
 // responses:
 //  200: productsResponse
+//  400: errorResponse
+//  429: tooManyRequestsResponse
 
 // ListAll handles GET requests and returns all current products
 func (p *Products) ListAll(rw http.ResponseWriter, r *http.Request) {
@@ -19,19 +24,56 @@ func (p *Products) ListAll(rw http.ResponseWriter, r *http.Request) {
 	// Extract query params from url
 	cur := r.URL.Query().Get("currency")
 
-	// fetch the products from the datastore
-	prods, err := p.db.GetProducts(cur)
+	q, err := getProductQuery(r)
+	if err != nil {
+		p.logger(r).Error("Invalid product query", "error", err)
 
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+
+	asOf, err := getAsOf(r)
 	if err != nil {
+		p.logger(r).Error("Invalid as_of date", "error", err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+
+	// fetch the products from the datastore
+	page, err := p.db.GetProducts(r.Context(), cur, asOf, q)
+
+	switch err {
+	case nil:
+
+	case data.ErrInvalidCursor, data.ErrInvalidSort, data.ErrInvalidLimit, data.ErrHistoricalRateNotFound, data.ErrUnknownCurrency, data.ErrRateNotFound, data.ErrInvalidRateRequest:
+		p.logger(r).Error("Unable to list products", "currency", cur, "error", err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	default:
+		p.logger(r).Error("Unable to fetch products", "error", err)
+
 		rw.WriteHeader(http.StatusInternalServerError)
 		data.ToJSON(&GenericError{Message: err.Error()}, rw)
 		return
 	}
 
+	setRateHeaders(rw, page.Rate)
+
+	// add the pagination headers
+	rw.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		rw.Header().Set("Link", nextPageLink(r, page.NextCursor))
+	}
+
 	// serialize the list to JSON
-	err = data.ToJSON(prods, rw)
+	err = data.ToJSON(page.Products, rw)
 	if err != nil {
-		p.l.Error("Unable to serialize product", "error", err)
+		p.logger(r).Error("Unable to serialize product", "error", err)
 	}
 }
 
@@ -39,7 +81,9 @@ func (p *Products) ListAll(rw http.ResponseWriter, r *http.Request) {
 // Returns a single product from the database
 // responses:
 //  200: productsResponse
+//  400: errorResponse
 //  404: errorResponse
+//  429: tooManyRequestsResponse
 
 // ListSingle handles GET requests
 func (p *Products) ListSingle(rw http.ResponseWriter, r *http.Request) {
@@ -50,30 +94,49 @@ func (p *Products) ListSingle(rw http.ResponseWriter, r *http.Request) {
 	cur := r.URL.Query().Get("currency")
 	id := getProductID(r)
 
-	p.l.Debug("Get record id", "id", id)
+	asOf, err := getAsOf(r)
+	if err != nil {
+		p.logger(r).Error("Invalid as_of date", "error", err)
 
-	prod, err := p.db.GetProductByID(id, cur)
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+
+	p.logger(r).Debug("Get record id", "id", id)
+
+	prod, rate, err := p.db.GetProductByID(r.Context(), id, cur, asOf)
 
 	switch err {
 	case nil:
 
+	case data.ErrHistoricalRateNotFound, data.ErrUnknownCurrency, data.ErrRateNotFound, data.ErrInvalidRateRequest:
+		p.logger(r).Error("Unable to get product", "id", id, "currency", cur, "error", err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+
 	case data.ErrProductNotFound:
-		p.l.Error("[ERROR] fetching product", err)
+		p.logger(r).Error("Unable to find product", "id", id, "error", err)
 
 		rw.WriteHeader(http.StatusNotFound)
 		data.ToJSON(&GenericError{Message: err.Error()}, rw)
 		return
 	default:
-		p.l.Error("[ERROR] fetching product", err)
+		p.logger(r).Error("Unable to fetch product", "id", id, "error", err)
 
 		rw.WriteHeader(http.StatusInternalServerError)
 		data.ToJSON(&GenericError{Message: err.Error()}, rw)
 		return
 	}
 
+	rw.Header().Set("ETag", productETag(prod.Version))
+	setRateHeaders(rw, rate)
+
 	err = data.ToJSON(prod, rw)
 	if err != nil {
 		// we should never be here but log the error just incase
-		p.l.Error("[ERROR] serializing product", err)
+		p.logger(r).Error("Unable to serialize product", "error", err)
 	}
 }
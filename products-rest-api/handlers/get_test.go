@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCurrencyClient rejects conversions of a currency to itself like the
+// currency server, has no rate for AED and returns a rate of 2 for every
+// other currency, the rate stream is never available
+type fakeCurrencyClient struct {
+	protos.CurrencyClient
+}
+
+func (f *fakeCurrencyClient) GetRates(ctx context.Context, rr *protos.RatesRequest, opts ...grpc.CallOption) (*protos.RatesResponse, error) {
+	res := &protos.RatesResponse{Base: rr.GetBase()}
+	for _, d := range rr.GetDestinations() {
+		if d == rr.GetBase() {
+			return nil, status.Errorf(codes.InvalidArgument, "Base currency %s can not be the same as the destination", d)
+		}
+		if d == "AED" {
+			return nil, status.Errorf(codes.NotFound, "Rate not found for %s", d)
+		}
+		res.Rates = append(res.Rates, &protos.RateResponse{Base: rr.GetBase(), Destination: d, Rate: 2})
+	}
+
+	return res, nil
+}
+
+func (f *fakeCurrencyClient) GetHistoricalRate(ctx context.Context, hr *protos.HistoricalRateRequest, opts ...grpc.CallOption) (*protos.HistoricalRateResponse, error) {
+	if hr.GetDestination() == hr.GetBase() {
+		return nil, status.Errorf(codes.InvalidArgument, "Base currency %s can not be the same as the destination", hr.GetBase())
+	}
+
+	return &protos.HistoricalRateResponse{Rate: 2, Date: hr.GetDate()}, nil
+}
+
+func (f *fakeCurrencyClient) SubscribeRates(ctx context.Context, opts ...grpc.CallOption) (protos.Currency_SubscribeRatesClient, error) {
+	return nil, status.Error(codes.Unavailable, "rate stream not available in tests")
+}
+
+// newTestRouter returns a router with the product read routes logging to l,
+// the ProductsDB is closed when the test finishes
+func newTestRouter(t *testing.T, l hclog.Logger) *mux.Router {
+	db := data.NewProductsDB(&fakeCurrencyClient{}, l, data.NewMemoryStore())
+	t.Cleanup(db.Close)
+	ph := NewProducts(l, data.NewValidation(), db)
+
+	sm := mux.NewRouter()
+	sm.HandleFunc("/products", ph.ListAll)
+	sm.HandleFunc("/products/search", ph.Search)
+	sm.HandleFunc("/products/{id:[0-9]+}", ph.ListSingle)
+
+	return sm
+}
+
+func TestGetProductsInBaseCurrency(t *testing.T) {
+	sm := newTestRouter(t, hclog.NewNullLogger())
+
+	for _, path := range []string{
+		"/products?currency=EUR",
+		"/products?currency=EUR&as_of=2020-08-03",
+		"/products/1?currency=EUR",
+		"/products/1?currency=EUR&as_of=2020-08-03",
+		"/products/search?q=latte&currency=EUR",
+	} {
+		t.Run(path, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			sm.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+			assert.Contains(t, rw.Body.String(), `"price":{"amount":2.45,"currency":"EUR"}`)
+		})
+	}
+}
+
+func TestGetProductsInOtherCurrency(t *testing.T) {
+	sm := newTestRouter(t, hclog.NewNullLogger())
+
+	rw := httptest.NewRecorder()
+	sm.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/products/1?currency=USD&as_of=2020-08-03", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	pr := &data.Product{}
+	assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), pr))
+	assert.Equal(t, "USD", pr.Price.Currency)
+	assert.Equal(t, "EUR", pr.BasePrice.Currency)
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, the rate stream of
+// the ProductsDB logs to it from another goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func TestGetProductsInCurrencyWithoutRate(t *testing.T) {
+	sm := newTestRouter(t, hclog.NewNullLogger())
+
+	for _, path := range []string{
+		"/products?currency=AED",
+		"/products/1?currency=AED",
+		"/products/search?q=latte&currency=AED",
+	} {
+		t.Run(path, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			sm.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+			ge := &GenericError{}
+			assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), ge))
+			assert.Equal(t, data.ErrRateNotFound.Error(), ge.Message)
+		})
+	}
+}
+
+func TestLogsRejectedRequests(t *testing.T) {
+	for _, path := range []string{
+		"/products?sort=colour",
+		"/products?as_of=yesterday",
+		"/products?currency=XYZ",
+		"/products?limit=101",
+		"/products?min_price=-1",
+		"/products/1?as_of=yesterday",
+		"/products/1?currency=XYZ",
+	} {
+		t.Run(path, func(t *testing.T) {
+			buf := &syncBuffer{}
+			sm := newTestRouter(t, hclog.New(&hclog.LoggerOptions{Output: buf}))
+
+			rw := httptest.NewRecorder()
+			sm.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+			ge := &GenericError{}
+			assert.NoError(t, json.Unmarshal(rw.Body.Bytes(), ge))
+			assert.Contains(t, buf.String(), ge.Message)
+		})
+	}
+}
@@ -9,9 +9,18 @@ import (
 // swagger:route POST /products products createProduct
 // Create a new product
 //
+// Requires the products:write scope
+//
+// security:
+//	api_key:
+//	bearer:
+//
 // responses:
 //	200: productResponse
+//  401: errorResponse
+//  403: errorResponse
 //  422: errorValidation
+//  429: tooManyRequestsResponse
 //  501: errorResponse
 
 // Create handles POST requests to add new products
@@ -20,7 +29,14 @@ func (p *Products) Create(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Add("Content-Type", "application/json")
 
 	prod := r.Context().Value(KeyProduct{}).(*data.Product)
-	p.l.Debug("Inserting Product", "debug", prod)
+	p.logger(r).Debug("Inserting product", "name", prod.Name)
+
+	err := p.db.AddProduct(r.Context(), prod)
+	if err != nil {
+		p.logger(r).Error("Unable to insert product", "error", err)
 
-	p.db.AddProduct(prod)
+		rw.WriteHeader(http.StatusInternalServerError)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
 }
@@ -2,13 +2,18 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-hclog"
+	"github.com/shopspring/decimal"
 )
 
 // KeyProduct is a key used for the Product object in the context
@@ -26,9 +31,21 @@ func NewProducts(l hclog.Logger, v *data.Validation, db *data.ProductsDB) *Produ
 	return &Products{l, v, db}
 }
 
+// logger returns the logger for the request, which logs the request ID
+func (p *Products) logger(r *http.Request) hclog.Logger {
+	return requestlog.Logger(r.Context(), p.l)
+}
+
 // ErrInvalidProductPath is an error message when the product path is not valid
 var ErrInvalidProductPath = fmt.Errorf("Invalid Path, path should be /products/[id]")
 
+// ErrInvalidAsOf is an error message when the as_of query parameter is not a valid date
+var ErrInvalidAsOf = fmt.Errorf("Invalid as_of, expected a date in the past in the format YYYY-MM-DD")
+
+// ErrInvalidETag is an error message when the If-Match header does not contain
+// an ETag issued by this API
+var ErrInvalidETag = fmt.Errorf("Invalid If-Match header, expected an ETag returned by GET /products/[id]")
+
 // GenericError is a generic error message returned by a server
 type GenericError struct {
 	Message string `json:"message"`
@@ -56,3 +73,112 @@ func getProductID(r *http.Request) int {
 
 	return id
 }
+
+// productETag returns the ETag header value for the given product version
+func productETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// getIfMatchVersion returns the product version from the If-Match header
+// returns 0 when the header is missing or "*" meaning any version matches
+func getIfMatchVersion(r *http.Request) (int, error) {
+	im := strings.TrimSpace(r.Header.Get("If-Match"))
+	if im == "" || im == "*" {
+		return 0, nil
+	}
+
+	// versions are compared for equality only so weak tags are accepted
+	im = strings.TrimPrefix(im, "W/")
+
+	v, err := strconv.Unquote(im)
+	if err != nil {
+		return 0, ErrInvalidETag
+	}
+
+	version, err := strconv.Atoi(v)
+	if err != nil || version < 1 {
+		return 0, ErrInvalidETag
+	}
+
+	return version, nil
+}
+
+// getProductQuery returns the filtering, sorting and paging options from the
+// URL query string
+func getProductQuery(r *http.Request) (*data.ProductQuery, error) {
+	v := r.URL.Query()
+
+	q := &data.ProductQuery{
+		Cursor:       v.Get("cursor"),
+		Sort:         v.Get("sort"),
+		NameContains: v.Get("name~"),
+		SKU:          v.Get("sku"),
+	}
+
+	var err error
+	if l := v.Get("limit"); l != "" {
+		q.Limit, err = strconv.Atoi(l)
+		if err != nil || q.Limit < 1 || q.Limit > data.MaxPageSize {
+			return nil, fmt.Errorf("Invalid limit %q, expected an integer from 1 to %d", l, data.MaxPageSize)
+		}
+	}
+
+	if mp := v.Get("min_price"); mp != "" {
+		d, err := decimal.NewFromString(mp)
+		if err != nil || d.IsNegative() {
+			return nil, fmt.Errorf("Invalid min_price %q, expected a number of zero or more", mp)
+		}
+		q.MinPrice = &d
+	}
+
+	if mp := v.Get("max_price"); mp != "" {
+		d, err := decimal.NewFromString(mp)
+		if err != nil || d.IsNegative() {
+			return nil, fmt.Errorf("Invalid max_price %q, expected a number of zero or more", mp)
+		}
+		q.MaxPrice = &d
+	}
+
+	return q, nil
+}
+
+// nextPageLink returns a Link header pointing at the page after the current
+// request, all other query parameters are kept
+func nextPageLink(r *http.Request, cursor string) string {
+	v := r.URL.Query()
+	v.Set("cursor", cursor)
+
+	u := url.URL{Path: r.URL.Path, RawQuery: v.Encode()}
+
+	return fmt.Sprintf(`<%s>; rel="next"`, u.String())
+}
+
+// getAsOf returns the date from the as_of query parameter used to price
+// products with historical rates, returns the zero time when not specified
+func getAsOf(r *http.Request) (time.Time, error) {
+	ao := r.URL.Query().Get("as_of")
+	if ao == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse("2006-01-02", ao)
+	if err != nil || t.After(time.Now()) {
+		return time.Time{}, ErrInvalidAsOf
+	}
+
+	return t, nil
+}
+
+// setRateHeaders sets the X-Rate-Timestamp header to the time the exchange
+// rate used to convert the prices was updated and X-Rate-Snapshot to the
+// snapshot it came from, nothing is set when the prices were not converted
+func setRateHeaders(rw http.ResponseWriter, rate *data.Rate) {
+	if rate == nil {
+		return
+	}
+
+	rw.Header().Set("X-Rate-Timestamp", rate.Updated.UTC().Format(time.RFC3339))
+	if rate.SnapshotID != "" {
+		rw.Header().Set("X-Rate-Snapshot", rate.SnapshotID)
+	}
+}
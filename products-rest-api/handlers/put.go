@@ -9,10 +9,21 @@ import (
 // swagger:route PUT /products products updateProduct
 // Update a products details
 //
+// Requires the products:write scope
+//
+// security:
+//	api_key:
+//	bearer:
+//
 // responses:
 //	201: noContentResponse
+//  400: errorResponse
+//  401: errorResponse
+//  403: errorResponse
 //  404: errorResponse
+//  409: errorResponse
 //  422: errorValidation
+//  429: tooManyRequestsResponse
 
 // Update handles PUT requests to update products
 func (p *Products) Update(rw http.ResponseWriter, r *http.Request) {
@@ -20,18 +31,45 @@ func (p *Products) Update(rw http.ResponseWriter, r *http.Request) {
 
 	// fetch the product from the context
 	prod := r.Context().Value(KeyProduct{}).(*data.Product)
-	p.l.Debug("Updating record id", "debug", prod.ID)
+	p.logger(r).Debug("Updating record", "id", prod.ID)
+
+	// the version to update comes from the If-Match header, not the body
+	version, err := getIfMatchVersion(r)
+	if err != nil {
+		p.logger(r).Error("Unable to parse If-Match header", "error", err)
+
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+	prod.Version = version
 
-	err := p.db.UpdateProduct(prod)
+	err = p.db.UpdateProduct(r.Context(), prod)
 
-	if err == data.ErrProductNotFound {
-		p.l.Error("Unable to find product ", "error", err)
+	switch err {
+	case nil:
+
+	case data.ErrProductNotFound:
+		p.logger(r).Error("Unable to find product", "id", prod.ID, "error", err)
 
 		rw.WriteHeader(http.StatusNotFound)
 		data.ToJSON(&GenericError{Message: "Product not found in database"}, rw)
 		return
+	case data.ErrVersionConflict:
+		p.logger(r).Error("Rejected stale product update", "id", prod.ID, "version", version)
+
+		rw.WriteHeader(http.StatusConflict)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	default:
+		p.logger(r).Error("Unable to update product", "error", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
 	}
 
-	// write the no content success header
+	// write the no content success header with the new version
+	rw.Header().Set("ETag", productETag(prod.Version))
 	rw.WriteHeader(http.StatusNoContent)
 }
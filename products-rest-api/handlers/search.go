@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
+)
+
+// swagger:route GET /products/search products searchProducts
+// Returns the products whose name or description match the query, most relevant
+// first. Terms match whole words, prefixes of words and words with small typos
+// responses:
+//  200: searchResponse
+//  400: errorResponse
+//  429: tooManyRequestsResponse
+
+// Search handles GET requests and returns the products matching the query
+func (p *Products) Search(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Add("Content-Type", "application/json")
+
+	// Extract query params from url
+	q := r.URL.Query().Get("q")
+	cur := r.URL.Query().Get("currency")
+
+	asOf, err := getAsOf(r)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+
+	p.logger(r).Debug("Search products", "query", q)
+
+	res, rate, err := p.db.SearchProducts(r.Context(), q, cur, asOf)
+
+	switch err {
+	case nil:
+
+	case data.ErrEmptySearch, data.ErrHistoricalRateNotFound, data.ErrUnknownCurrency, data.ErrRateNotFound, data.ErrInvalidRateRequest:
+		rw.WriteHeader(http.StatusBadRequest)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	default:
+		p.logger(r).Error("Unable to search products", "error", err)
+
+		rw.WriteHeader(http.StatusInternalServerError)
+		data.ToJSON(&GenericError{Message: err.Error()}, rw)
+		return
+	}
+
+	setRateHeaders(rw, rate)
+
+	err = data.ToJSON(res, rw)
+	if err != nil {
+		p.logger(r).Error("Unable to serialize search results", "error", err)
+	}
+}
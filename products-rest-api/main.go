@@ -3,31 +3,80 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 
 	"net/http"
 	"os"
 	"os/signal"
-	"time"
 
+	"github.com/JamieBShaw/golang-mux-rest-api/auth"
+	"github.com/JamieBShaw/golang-mux-rest-api/config"
 	protos "github.com/JamieBShaw/golang-mux-rest-api/currency/protos/currencypb"
+	"github.com/JamieBShaw/golang-mux-rest-api/health"
+	"github.com/JamieBShaw/golang-mux-rest-api/metrics"
 	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/data"
 	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/handlers"
+	"github.com/JamieBShaw/golang-mux-rest-api/ratelimit"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"github.com/JamieBShaw/golang-mux-rest-api/tracing"
 	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	muxtrace "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux"
+	grpctrace "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc"
+	"go.opentelemetry.io/otel/api/global"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 
 	"github.com/go-openapi/runtime/middleware"
 	goHandlers "github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 )
 
-var serverAddr = flag.String("server_addr", "localhost:9092", "grpc server in format host:port")
+// serviceName identifies the logs, flags and spans of this service
+const serviceName = "products-rest-api"
 
 func main() {
+	cfg := &Config{}
+	err := config.Load(serviceName, "PRODUCTS_API", cfg, os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		hclog.Default().Error("Unable to load configuration", "error", err)
+		os.Exit(2)
+	}
+
+	l := hclog.New(&hclog.LoggerOptions{
+		Name:  serviceName,
+		Level: hclog.LevelFromString(cfg.LogLevel),
+	})
+	l.Info("Loaded configuration", config.Values(cfg)...)
 
-	l := hclog.Default()
 	v := data.NewValidation()
 
-	conn, err := grpc.Dial(*serverAddr, grpc.WithInsecure())
+	closeTracing, err := tracing.Setup(serviceName, cfg.TraceOutput)
+	if err != nil {
+		l.Error("Unable to set up tracing", "output", cfg.TraceOutput, "error", err)
+		os.Exit(1)
+	}
+	defer closeTracing()
+
+	creds, err := cfg.currencyCredentials()
+	if err != nil {
+		l.Error("Unable to configure TLS for the currency server", "error", err)
+		os.Exit(1)
+	}
+
+	// trace the calls to the currency service as children of the request and
+	// send the request ID with them
+	tracer := global.Tracer(serviceName)
+	conn, err := grpc.Dial(
+		cfg.ServerAddr,
+		append(creds,
+			grpc.WithChainUnaryInterceptor(grpctrace.UnaryClientInterceptor(tracer), requestlog.UnaryClientInterceptor),
+			grpc.WithChainStreamInterceptor(grpctrace.StreamClientInterceptor(tracer), requestlog.StreamClientInterceptor),
+		)...,
+	)
 
 	if err != nil {
 		panic(err)
@@ -38,32 +87,104 @@ func main() {
 	// create currency client
 	cc := protos.NewCurrencyClient(conn)
 
+	// create the product store, the type is checked when loading the config
+	var ps data.ProductStore = data.NewMemoryStore()
+	if cfg.Store == "sqlite" {
+		ss, err := data.NewSQLiteStore(cfg.DBPath)
+		if err != nil {
+			l.Error("Unable to open product store", "path", cfg.DBPath, "error", err)
+			os.Exit(1)
+		}
+		defer ss.Close()
+
+		ps = ss
+	}
+
 	// create database instance
-	db := data.NewProductsDB(cc, l)
+	db := data.NewProductsDB(cc, l, ps)
+	defer db.Close()
 
 	// create the handlers
 	ph := handlers.NewProducts(l, v, db)
+	curH := handlers.NewCurrencies(l, db)
+	hh := health.NewHandler(l, map[string]health.Check{
+		"currency": func() error {
+			if s := conn.GetState(); s != connectivity.Ready {
+				return fmt.Errorf("Connection to the currency server is %s", s)
+			}
+			return nil
+		},
+		"rate_stream": db.CheckRateStream,
+	})
+
+	// changes to the products require the products:write scope
+	ac, err := auth.LoadConfig(cfg.JWKSFile, cfg.APIKeysFile)
+	if err != nil {
+		l.Error("Unable to load authentication keys", "error", err)
+		os.Exit(1)
+	}
+	if ac.Keys == nil && len(ac.APIKeys) == 0 {
+		l.Warn("No JWKS or API keys configured, changes to products will be rejected")
+	}
+	ac.Issuer, ac.Audience = cfg.JWTIssuer, cfg.JWTAudience
+	authn := auth.NewAuthenticator(ac, l)
+
+	// limit the requests of each client, changes are limited once the caller
+	// is authenticated so they are limited by API key or token subject. They
+	// are also limited by IP address before authentication so rejected
+	// credentials can not be tried without limit
+	reads, writes, authLimit, err := cfg.rateLimits()
+	if err != nil {
+		l.Error("Invalid rate limits", "error", err)
+		os.Exit(1)
+	}
+	store := ratelimit.NewMemoryStore()
+	limiter := ratelimit.NewLimiter(store, ratelimit.ClientKey, l)
+	ipLimiter := ratelimit.NewLimiter(store, ratelimit.IPKey, l)
 
 	// create a new serve mux and register the handlers
 	sm := mux.NewRouter()
 
+	// identify, log, trace and record the requests to every route
+	sm.Use(requestlog.Middleware(l))
+	sm.Use(muxtrace.Middleware(serviceName))
+	sm.Use(metrics.Middleware)
+
+	// probes and metrics are not rate limited
+	opsR := sm.Methods(http.MethodGet).Subrouter()
+	opsR.HandleFunc("/healthz", hh.Live)
+	opsR.HandleFunc("/readyz", hh.Ready)
+	opsR.Handle("/metrics", promhttp.Handler())
+
 	// handlers for API
 	getR := sm.Methods(http.MethodGet).Subrouter()
 	getR.HandleFunc("/products", ph.ListAll)
 	getR.HandleFunc("/products", ph.ListAll).Queries("currency", "{[A-Z]{3}}")
+	getR.HandleFunc("/products/search", ph.Search)
 	getR.HandleFunc("/products/{id:[0-9]+}", ph.ListSingle)
 	getR.HandleFunc("/products/{id:[0-9]+}", ph.ListAll).Queries("currency", "{[A-Z]{3}}")
+	getR.HandleFunc("/currencies", curH.ListAll)
+	getR.Use(limiter.Middleware("reads", reads))
 
 	putR := sm.Methods(http.MethodPut).Subrouter()
 	putR.HandleFunc("/products", ph.Update)
+	putR.Use(ipLimiter.Middleware("auth", authLimit))
+	putR.Use(authn.Require("products:write"))
+	putR.Use(limiter.Middleware("writes", writes))
 	putR.Use(ph.MiddlewareValidateProduct)
 
 	postR := sm.Methods(http.MethodPost).Subrouter()
 	postR.HandleFunc("/products", ph.Create)
+	postR.Use(ipLimiter.Middleware("auth", authLimit))
+	postR.Use(authn.Require("products:write"))
+	postR.Use(limiter.Middleware("writes", writes))
 	postR.Use(ph.MiddlewareValidateProduct)
 
 	deleteR := sm.Methods(http.MethodDelete).Subrouter()
 	deleteR.HandleFunc("/products/{id:[0-9]+}", ph.Delete)
+	deleteR.Use(ipLimiter.Middleware("auth", authLimit))
+	deleteR.Use(authn.Require("products:write"))
+	deleteR.Use(limiter.Middleware("writes", writes))
 
 	// documentation handlers
 	opts := middleware.RedocOpts{SpecURL: "/swagger.swag.yaml"}
@@ -74,23 +195,26 @@ func main() {
 
 	// CORS
 
-	ch := goHandlers.CORS(goHandlers.AllowedOrigins([]string{"http://localhost:3000"}))
+	ch := goHandlers.CORS(
+		goHandlers.AllowedOrigins(cfg.CORSOrigins),
+		goHandlers.AllowedHeaders([]string{"Content-Type", "Authorization", auth.HeaderAPIKey}),
+	)
 
 	// create a new server
 	s := &http.Server{
-		Addr:         ":9090",
+		Addr:         cfg.ListenAddr,
 		Handler:      ch(sm),
 		ErrorLog:     l.StandardLogger(&hclog.StandardLoggerOptions{}),
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  120 * time.Second,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
 	}
 	go func() {
-		l.Info("Starting server on port 9090")
+		l.Info("Starting server", "listen_addr", cfg.ListenAddr)
 
 		err := s.ListenAndServe()
-		if err != nil {
-			l.Error("Error starting server: %s\n", err)
+		if err != nil && err != http.ErrServerClosed {
+			l.Error("Unable to start server", "error", err)
 			os.Exit(1)
 		}
 	}()
@@ -102,9 +226,10 @@ func main() {
 
 	// Block until a signal is received.
 	sig := <-c
-	l.Info("Got signal:", sig)
+	l.Info("Shutting down server", "signal", sig)
 
-	// gracefully shutdown the server, waiting max 30 seconds for current operations to complete
-	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+	// gracefully shutdown the server, waiting for current operations to complete
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
 	s.Shutdown(ctx)
 }
@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package currencies
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewListCurrenciesParams creates a new ListCurrenciesParams object
+// with the default values initialized.
+func NewListCurrenciesParams() *ListCurrenciesParams {
+
+	return &ListCurrenciesParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListCurrenciesParamsWithTimeout creates a new ListCurrenciesParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListCurrenciesParamsWithTimeout(timeout time.Duration) *ListCurrenciesParams {
+
+	return &ListCurrenciesParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewListCurrenciesParamsWithContext creates a new ListCurrenciesParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListCurrenciesParamsWithContext(ctx context.Context) *ListCurrenciesParams {
+
+	return &ListCurrenciesParams{
+
+		Context: ctx,
+	}
+}
+
+// NewListCurrenciesParamsWithHTTPClient creates a new ListCurrenciesParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListCurrenciesParamsWithHTTPClient(client *http.Client) *ListCurrenciesParams {
+
+	return &ListCurrenciesParams{
+		HTTPClient: client,
+	}
+}
+
+/*ListCurrenciesParams contains all the parameters to send to the API endpoint
+for the list currencies operation typically these are written to a http.Request
+*/
+type ListCurrenciesParams struct {
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list currencies params
+func (o *ListCurrenciesParams) WithTimeout(timeout time.Duration) *ListCurrenciesParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list currencies params
+func (o *ListCurrenciesParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list currencies params
+func (o *ListCurrenciesParams) WithContext(ctx context.Context) *ListCurrenciesParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list currencies params
+func (o *ListCurrenciesParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list currencies params
+func (o *ListCurrenciesParams) WithHTTPClient(client *http.Client) *ListCurrenciesParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list currencies params
+func (o *ListCurrenciesParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListCurrenciesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package currencies
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
+)
+
+// ListCurrenciesReader is a Reader for the ListCurrencies structure.
+type ListCurrenciesReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListCurrenciesReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListCurrenciesOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 429:
+		result := NewListCurrenciesTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewListCurrenciesInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewListCurrenciesOK creates a ListCurrenciesOK with default headers values
+func NewListCurrenciesOK() *ListCurrenciesOK {
+	return &ListCurrenciesOK{}
+}
+
+/*ListCurrenciesOK handles this case with default header values.
+
+A list of currencies
+*/
+type ListCurrenciesOK struct {
+	Payload []*models.Currency
+}
+
+func (o *ListCurrenciesOK) Error() string {
+	return fmt.Sprintf("[GET /currencies][%d] listCurrenciesOK  %+v", 200, o.Payload)
+}
+
+func (o *ListCurrenciesOK) GetPayload() []*models.Currency {
+	return o.Payload
+}
+
+func (o *ListCurrenciesOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListCurrenciesTooManyRequests creates a ListCurrenciesTooManyRequests with default headers values
+func NewListCurrenciesTooManyRequests() *ListCurrenciesTooManyRequests {
+	return &ListCurrenciesTooManyRequests{}
+}
+
+/*ListCurrenciesTooManyRequests handles this case with default header values.
+
+The client has made too many requests, retry after the time in the
+Retry-After header
+*/
+type ListCurrenciesTooManyRequests struct {
+	/*Number of requests the client can make in a burst
+	 */
+	RateLimitLimit int64
+	/*Number of requests the client can make now
+	 */
+	RateLimitRemaining int64
+	/*Seconds until the client can make a full burst of requests again
+	 */
+	RateLimitReset int64
+	/*Seconds until the client can make the next request
+	 */
+	RetryAfter int64
+
+	Payload *models.GenericError
+}
+
+func (o *ListCurrenciesTooManyRequests) Error() string {
+	return fmt.Sprintf("[GET /currencies][%d] listCurrenciesTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *ListCurrenciesTooManyRequests) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ListCurrenciesTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header RateLimit-Limit
+	rateLimitLimit, err := swag.ConvertInt64(response.GetHeader("RateLimit-Limit"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Limit", "header", "int64", response.GetHeader("RateLimit-Limit"))
+	}
+	o.RateLimitLimit = rateLimitLimit
+
+	// response header RateLimit-Remaining
+	rateLimitRemaining, err := swag.ConvertInt64(response.GetHeader("RateLimit-Remaining"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Remaining", "header", "int64", response.GetHeader("RateLimit-Remaining"))
+	}
+	o.RateLimitRemaining = rateLimitRemaining
+
+	// response header RateLimit-Reset
+	rateLimitReset, err := swag.ConvertInt64(response.GetHeader("RateLimit-Reset"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Reset", "header", "int64", response.GetHeader("RateLimit-Reset"))
+	}
+	o.RateLimitReset = rateLimitReset
+
+	// response header Retry-After
+	retryAfter, err := swag.ConvertInt64(response.GetHeader("Retry-After"))
+	if err != nil {
+		return errors.InvalidType("Retry-After", "header", "int64", response.GetHeader("Retry-After"))
+	}
+	o.RetryAfter = retryAfter
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListCurrenciesInternalServerError creates a ListCurrenciesInternalServerError with default headers values
+func NewListCurrenciesInternalServerError() *ListCurrenciesInternalServerError {
+	return &ListCurrenciesInternalServerError{}
+}
+
+/*ListCurrenciesInternalServerError handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type ListCurrenciesInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *ListCurrenciesInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /currencies][%d] listCurrenciesInternalServerError  %+v", 500, o.Payload)
+}
+
+func (o *ListCurrenciesInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ListCurrenciesInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
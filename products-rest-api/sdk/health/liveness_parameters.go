@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package health
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewLivenessParams creates a new LivenessParams object
+// with the default values initialized.
+func NewLivenessParams() *LivenessParams {
+
+	return &LivenessParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewLivenessParamsWithTimeout creates a new LivenessParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewLivenessParamsWithTimeout(timeout time.Duration) *LivenessParams {
+
+	return &LivenessParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewLivenessParamsWithContext creates a new LivenessParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewLivenessParamsWithContext(ctx context.Context) *LivenessParams {
+
+	return &LivenessParams{
+
+		Context: ctx,
+	}
+}
+
+// NewLivenessParamsWithHTTPClient creates a new LivenessParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewLivenessParamsWithHTTPClient(client *http.Client) *LivenessParams {
+
+	return &LivenessParams{
+		HTTPClient: client,
+	}
+}
+
+/*LivenessParams contains all the parameters to send to the API endpoint
+for the liveness operation typically these are written to a http.Request
+*/
+type LivenessParams struct {
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the liveness params
+func (o *LivenessParams) WithTimeout(timeout time.Duration) *LivenessParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the liveness params
+func (o *LivenessParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the liveness params
+func (o *LivenessParams) WithContext(ctx context.Context) *LivenessParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the liveness params
+func (o *LivenessParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the liveness params
+func (o *LivenessParams) WithHTTPClient(client *http.Client) *LivenessParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the liveness params
+func (o *LivenessParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *LivenessParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
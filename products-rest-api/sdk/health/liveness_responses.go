@@ -0,0 +1,69 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package health
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
+)
+
+// LivenessReader is a Reader for the Liveness structure.
+type LivenessReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *LivenessReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewLivenessOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewLivenessOK creates a LivenessOK with default headers values
+func NewLivenessOK() *LivenessOK {
+	return &LivenessOK{}
+}
+
+/*LivenessOK handles this case with default header values.
+
+Health of the service
+*/
+type LivenessOK struct {
+	Payload *models.HealthStatus
+}
+
+func (o *LivenessOK) Error() string {
+	return fmt.Sprintf("[GET /healthz][%d] livenessOK  %+v", 200, o.Payload)
+}
+
+func (o *LivenessOK) GetPayload() *models.HealthStatus {
+	return o.Payload
+}
+
+func (o *LivenessOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.HealthStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
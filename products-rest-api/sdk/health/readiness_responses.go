@@ -0,0 +1,108 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package health
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
+)
+
+// ReadinessReader is a Reader for the Readiness structure.
+type ReadinessReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ReadinessReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewReadinessOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 503:
+		result := NewReadinessServiceUnavailable()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewReadinessOK creates a ReadinessOK with default headers values
+func NewReadinessOK() *ReadinessOK {
+	return &ReadinessOK{}
+}
+
+/*ReadinessOK handles this case with default header values.
+
+Health of the service
+*/
+type ReadinessOK struct {
+	Payload *models.HealthStatus
+}
+
+func (o *ReadinessOK) Error() string {
+	return fmt.Sprintf("[GET /readyz][%d] readinessOK  %+v", 200, o.Payload)
+}
+
+func (o *ReadinessOK) GetPayload() *models.HealthStatus {
+	return o.Payload
+}
+
+func (o *ReadinessOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.HealthStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewReadinessServiceUnavailable creates a ReadinessServiceUnavailable with default headers values
+func NewReadinessServiceUnavailable() *ReadinessServiceUnavailable {
+	return &ReadinessServiceUnavailable{}
+}
+
+/*ReadinessServiceUnavailable handles this case with default header values.
+
+Health of the service
+*/
+type ReadinessServiceUnavailable struct {
+	Payload *models.HealthStatus
+}
+
+func (o *ReadinessServiceUnavailable) Error() string {
+	return fmt.Sprintf("[GET /readyz][%d] readinessServiceUnavailable  %+v", 503, o.Payload)
+}
+
+func (o *ReadinessServiceUnavailable) GetPayload() *models.HealthStatus {
+	return o.Payload
+}
+
+func (o *ReadinessServiceUnavailable) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.HealthStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Currency Currency is a currency product prices can be converted to
+//
+// swagger:model Currency
+type Currency struct {
+
+	// the ISO 4217 currency code
+	Code string `json:"code,omitempty"`
+
+	// the English name of the currency
+	Name string `json:"name,omitempty"`
+
+	// the source of the exchange rate on the currency service
+	Source string `json:"source,omitempty"`
+
+	// the symbol commonly used for the currency
+	Symbol string `json:"symbol,omitempty"`
+
+	// when the exchange rate for the currency was last updated
+	// Format: date-time
+	Updated strfmt.DateTime `json:"updated,omitempty"`
+}
+
+// Validate validates this currency
+func (m *Currency) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateUpdated(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Currency) validateUpdated(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Updated) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("updated", "body", "date-time", m.Updated.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Currency) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Currency) UnmarshalBinary(b []byte) error {
+	var res Currency
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -30,15 +30,21 @@ type Product struct {
 	// Max Length: 255
 	Name *string `json:"name"`
 
-	// the price for the product
-	// Required: true
-	// Minimum: 0.01
-	Price *float32 `json:"price"`
-
 	// the SKU for the product
 	// Required: true
-	// Pattern: [a-z]+-[a-z]+-[a-z]+
+	// Pattern: ^[a-z]+-[a-z]+-[a-z]+$
 	SKU *string `json:"sku"`
+
+	// the version of the product, incremented every time it is updated
+	// Read Only: true
+	Version int64 `json:"version,omitempty"`
+
+	// base price
+	BasePrice *Money `json:"base_price,omitempty"`
+
+	// price
+	// Required: true
+	Price *Money `json:"price"`
 }
 
 // Validate validates this product
@@ -57,11 +63,15 @@ func (m *Product) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
-	if err := m.validatePrice(formats); err != nil {
+	if err := m.validateSKU(formats); err != nil {
 		res = append(res, err)
 	}
 
-	if err := m.validateSKU(formats); err != nil {
+	if err := m.validateBasePrice(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePrice(formats); err != nil {
 		res = append(res, err)
 	}
 
@@ -110,29 +120,52 @@ func (m *Product) validateName(formats strfmt.Registry) error {
 	return nil
 }
 
-func (m *Product) validatePrice(formats strfmt.Registry) error {
+func (m *Product) validateSKU(formats strfmt.Registry) error {
 
-	if err := validate.Required("price", "body", m.Price); err != nil {
+	if err := validate.Required("sku", "body", m.SKU); err != nil {
 		return err
 	}
 
-	if err := validate.Minimum("price", "body", float64(*m.Price), 0.01, false); err != nil {
+	if err := validate.Pattern("sku", "body", string(*m.SKU), `^[a-z]+-[a-z]+-[a-z]+$`); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (m *Product) validateSKU(formats strfmt.Registry) error {
+func (m *Product) validateBasePrice(formats strfmt.Registry) error {
 
-	if err := validate.Required("sku", "body", m.SKU); err != nil {
-		return err
+	if swag.IsZero(m.BasePrice) { // not required
+		return nil
+	}
+
+	if m.BasePrice != nil {
+		if err := m.BasePrice.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("base_price")
+			}
+			return err
+		}
 	}
 
-	if err := validate.Pattern("sku", "body", string(*m.SKU), `[a-z]+-[a-z]+-[a-z]+`); err != nil {
+	return nil
+}
+
+func (m *Product) validatePrice(formats strfmt.Registry) error {
+
+	if err := validate.Required("price", "body", m.Price); err != nil {
 		return err
 	}
 
+	if m.Price != nil {
+		if err := m.Price.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("price")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
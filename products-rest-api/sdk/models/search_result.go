@@ -0,0 +1,74 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// SearchResult SearchResult is a product matching a search query with its relevance score
+//
+// swagger:model SearchResult
+type SearchResult struct {
+
+	// relevance of the product to the query, higher is more relevant
+	Score float64 `json:"score,omitempty"`
+
+	// product
+	Product *Product `json:"product,omitempty"`
+}
+
+// Validate validates this search result
+func (m *SearchResult) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateProduct(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SearchResult) validateProduct(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Product) { // not required
+		return nil
+	}
+
+	if m.Product != nil {
+		if err := m.Product.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("product")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SearchResult) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SearchResult) UnmarshalBinary(b []byte) error {
+	var res SearchResult
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
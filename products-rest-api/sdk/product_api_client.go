@@ -10,7 +10,9 @@ import (
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
-	"github/JamieBShaw/golang-mux-rest-api/client/products"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/currencies"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/health"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/products"
 )
 
 // Default product API HTTP client.
@@ -55,6 +57,8 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *ProductAPI
 
 	cli := new(ProductAPI)
 	cli.Transport = transport
+	cli.Currencies = currencies.New(transport, formats)
+	cli.Health = health.New(transport, formats)
 	cli.Products = products.New(transport, formats)
 	return cli
 }
@@ -100,6 +104,10 @@ func (cfg *TransportConfig) WithSchemes(schemes []string) *TransportConfig {
 
 // ProductAPI is a client for product API
 type ProductAPI struct {
+	Currencies currencies.ClientService
+
+	Health health.ClientService
+
 	Products products.ClientService
 
 	Transport runtime.ClientTransport
@@ -108,5 +116,7 @@ type ProductAPI struct {
 // SetTransport changes the transport on the client and all its subresources
 func (c *ProductAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Transport = transport
+	c.Currencies.SetTransport(transport)
+	c.Health.SetTransport(transport)
 	c.Products.SetTransport(transport)
 }
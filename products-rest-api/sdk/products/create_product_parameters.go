@@ -15,7 +15,7 @@ import (
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
-	"github/JamieBShaw/golang-mux-rest-api/models"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
 )
 
 // NewCreateProductParams creates a new CreateProductParams object
@@ -65,6 +65,7 @@ type CreateProductParams struct {
 	/*Body
 	  Product data structure to Update or Create.
 	Note: the id field is ignored by update and create operations
+	and the version field is ignored, use the If-Match header instead
 
 	*/
 	Body *models.Product
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
-	"github/JamieBShaw/golang-mux-rest-api/models"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
 )
 
 // CreateProductReader is a Reader for the CreateProduct structure.
@@ -29,12 +31,30 @@ func (o *CreateProductReader) ReadResponse(response runtime.ClientResponse, cons
 			return nil, err
 		}
 		return result, nil
+	case 401:
+		result := NewCreateProductUnauthorized()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 403:
+		result := NewCreateProductForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 422:
 		result := NewCreateProductUnprocessableEntity()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
 		return nil, result
+	case 429:
+		result := NewCreateProductTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 501:
 		result := NewCreateProductNotImplemented()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -80,6 +100,72 @@ func (o *CreateProductOK) readResponse(response runtime.ClientResponse, consumer
 	return nil
 }
 
+// NewCreateProductUnauthorized creates a CreateProductUnauthorized with default headers values
+func NewCreateProductUnauthorized() *CreateProductUnauthorized {
+	return &CreateProductUnauthorized{}
+}
+
+/*CreateProductUnauthorized handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type CreateProductUnauthorized struct {
+	Payload *models.GenericError
+}
+
+func (o *CreateProductUnauthorized) Error() string {
+	return fmt.Sprintf("[POST /products][%d] createProductUnauthorized  %+v", 401, o.Payload)
+}
+
+func (o *CreateProductUnauthorized) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CreateProductUnauthorized) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateProductForbidden creates a CreateProductForbidden with default headers values
+func NewCreateProductForbidden() *CreateProductForbidden {
+	return &CreateProductForbidden{}
+}
+
+/*CreateProductForbidden handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type CreateProductForbidden struct {
+	Payload *models.GenericError
+}
+
+func (o *CreateProductForbidden) Error() string {
+	return fmt.Sprintf("[POST /products][%d] createProductForbidden  %+v", 403, o.Payload)
+}
+
+func (o *CreateProductForbidden) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CreateProductForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewCreateProductUnprocessableEntity creates a CreateProductUnprocessableEntity with default headers values
 func NewCreateProductUnprocessableEntity() *CreateProductUnprocessableEntity {
 	return &CreateProductUnprocessableEntity{}
@@ -113,6 +199,81 @@ func (o *CreateProductUnprocessableEntity) readResponse(response runtime.ClientR
 	return nil
 }
 
+// NewCreateProductTooManyRequests creates a CreateProductTooManyRequests with default headers values
+func NewCreateProductTooManyRequests() *CreateProductTooManyRequests {
+	return &CreateProductTooManyRequests{}
+}
+
+/*CreateProductTooManyRequests handles this case with default header values.
+
+The client has made too many requests, retry after the time in the
+Retry-After header
+*/
+type CreateProductTooManyRequests struct {
+	/*Number of requests the client can make in a burst
+	 */
+	RateLimitLimit int64
+	/*Number of requests the client can make now
+	 */
+	RateLimitRemaining int64
+	/*Seconds until the client can make a full burst of requests again
+	 */
+	RateLimitReset int64
+	/*Seconds until the client can make the next request
+	 */
+	RetryAfter int64
+
+	Payload *models.GenericError
+}
+
+func (o *CreateProductTooManyRequests) Error() string {
+	return fmt.Sprintf("[POST /products][%d] createProductTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *CreateProductTooManyRequests) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CreateProductTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header RateLimit-Limit
+	rateLimitLimit, err := swag.ConvertInt64(response.GetHeader("RateLimit-Limit"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Limit", "header", "int64", response.GetHeader("RateLimit-Limit"))
+	}
+	o.RateLimitLimit = rateLimitLimit
+
+	// response header RateLimit-Remaining
+	rateLimitRemaining, err := swag.ConvertInt64(response.GetHeader("RateLimit-Remaining"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Remaining", "header", "int64", response.GetHeader("RateLimit-Remaining"))
+	}
+	o.RateLimitRemaining = rateLimitRemaining
+
+	// response header RateLimit-Reset
+	rateLimitReset, err := swag.ConvertInt64(response.GetHeader("RateLimit-Reset"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Reset", "header", "int64", response.GetHeader("RateLimit-Reset"))
+	}
+	o.RateLimitReset = rateLimitReset
+
+	// response header Retry-After
+	retryAfter, err := swag.ConvertInt64(response.GetHeader("Retry-After"))
+	if err != nil {
+		return errors.InvalidType("Retry-After", "header", "int64", response.GetHeader("Retry-After"))
+	}
+	o.RetryAfter = retryAfter
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewCreateProductNotImplemented creates a CreateProductNotImplemented with default headers values
 func NewCreateProductNotImplemented() *CreateProductNotImplemented {
 	return &CreateProductNotImplemented{}
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
-	"github/JamieBShaw/golang-mux-rest-api/models"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
 )
 
 // DeleteProductReader is a Reader for the DeleteProduct structure.
@@ -29,12 +31,30 @@ func (o *DeleteProductReader) ReadResponse(response runtime.ClientResponse, cons
 			return nil, err
 		}
 		return result, nil
+	case 401:
+		result := NewDeleteProductUnauthorized()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 403:
+		result := NewDeleteProductForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 404:
 		result := NewDeleteProductNotFound()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
 		return nil, result
+	case 429:
+		result := NewDeleteProductTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 501:
 		result := NewDeleteProductNotImplemented()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -68,6 +88,72 @@ func (o *DeleteProductCreated) readResponse(response runtime.ClientResponse, con
 	return nil
 }
 
+// NewDeleteProductUnauthorized creates a DeleteProductUnauthorized with default headers values
+func NewDeleteProductUnauthorized() *DeleteProductUnauthorized {
+	return &DeleteProductUnauthorized{}
+}
+
+/*DeleteProductUnauthorized handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type DeleteProductUnauthorized struct {
+	Payload *models.GenericError
+}
+
+func (o *DeleteProductUnauthorized) Error() string {
+	return fmt.Sprintf("[DELETE /products/{id}][%d] deleteProductUnauthorized  %+v", 401, o.Payload)
+}
+
+func (o *DeleteProductUnauthorized) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *DeleteProductUnauthorized) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDeleteProductForbidden creates a DeleteProductForbidden with default headers values
+func NewDeleteProductForbidden() *DeleteProductForbidden {
+	return &DeleteProductForbidden{}
+}
+
+/*DeleteProductForbidden handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type DeleteProductForbidden struct {
+	Payload *models.GenericError
+}
+
+func (o *DeleteProductForbidden) Error() string {
+	return fmt.Sprintf("[DELETE /products/{id}][%d] deleteProductForbidden  %+v", 403, o.Payload)
+}
+
+func (o *DeleteProductForbidden) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *DeleteProductForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDeleteProductNotFound creates a DeleteProductNotFound with default headers values
 func NewDeleteProductNotFound() *DeleteProductNotFound {
 	return &DeleteProductNotFound{}
@@ -101,6 +187,81 @@ func (o *DeleteProductNotFound) readResponse(response runtime.ClientResponse, co
 	return nil
 }
 
+// NewDeleteProductTooManyRequests creates a DeleteProductTooManyRequests with default headers values
+func NewDeleteProductTooManyRequests() *DeleteProductTooManyRequests {
+	return &DeleteProductTooManyRequests{}
+}
+
+/*DeleteProductTooManyRequests handles this case with default header values.
+
+The client has made too many requests, retry after the time in the
+Retry-After header
+*/
+type DeleteProductTooManyRequests struct {
+	/*Number of requests the client can make in a burst
+	 */
+	RateLimitLimit int64
+	/*Number of requests the client can make now
+	 */
+	RateLimitRemaining int64
+	/*Seconds until the client can make a full burst of requests again
+	 */
+	RateLimitReset int64
+	/*Seconds until the client can make the next request
+	 */
+	RetryAfter int64
+
+	Payload *models.GenericError
+}
+
+func (o *DeleteProductTooManyRequests) Error() string {
+	return fmt.Sprintf("[DELETE /products/{id}][%d] deleteProductTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *DeleteProductTooManyRequests) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *DeleteProductTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header RateLimit-Limit
+	rateLimitLimit, err := swag.ConvertInt64(response.GetHeader("RateLimit-Limit"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Limit", "header", "int64", response.GetHeader("RateLimit-Limit"))
+	}
+	o.RateLimitLimit = rateLimitLimit
+
+	// response header RateLimit-Remaining
+	rateLimitRemaining, err := swag.ConvertInt64(response.GetHeader("RateLimit-Remaining"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Remaining", "header", "int64", response.GetHeader("RateLimit-Remaining"))
+	}
+	o.RateLimitRemaining = rateLimitRemaining
+
+	// response header RateLimit-Reset
+	rateLimitReset, err := swag.ConvertInt64(response.GetHeader("RateLimit-Reset"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Reset", "header", "int64", response.GetHeader("RateLimit-Reset"))
+	}
+	o.RateLimitReset = rateLimitReset
+
+	// response header Retry-After
+	retryAfter, err := swag.ConvertInt64(response.GetHeader("Retry-After"))
+	if err != nil {
+		return errors.InvalidType("Retry-After", "header", "int64", response.GetHeader("Retry-After"))
+	}
+	o.RetryAfter = retryAfter
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewDeleteProductNotImplemented creates a DeleteProductNotImplemented with default headers values
 func NewDeleteProductNotImplemented() *DeleteProductNotImplemented {
 	return &DeleteProductNotImplemented{}
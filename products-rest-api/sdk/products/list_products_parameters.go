@@ -14,12 +14,13 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListProductsParams creates a new ListProductsParams object
 // with the default values initialized.
 func NewListProductsParams() *ListProductsParams {
-
+	var ()
 	return &ListProductsParams{
 
 		timeout: cr.DefaultTimeout,
@@ -29,7 +30,7 @@ func NewListProductsParams() *ListProductsParams {
 // NewListProductsParamsWithTimeout creates a new ListProductsParams object
 // with the default values initialized, and the ability to set a timeout on a request
 func NewListProductsParamsWithTimeout(timeout time.Duration) *ListProductsParams {
-
+	var ()
 	return &ListProductsParams{
 
 		timeout: timeout,
@@ -39,7 +40,7 @@ func NewListProductsParamsWithTimeout(timeout time.Duration) *ListProductsParams
 // NewListProductsParamsWithContext creates a new ListProductsParams object
 // with the default values initialized, and the ability to set a context for a request
 func NewListProductsParamsWithContext(ctx context.Context) *ListProductsParams {
-
+	var ()
 	return &ListProductsParams{
 
 		Context: ctx,
@@ -49,7 +50,7 @@ func NewListProductsParamsWithContext(ctx context.Context) *ListProductsParams {
 // NewListProductsParamsWithHTTPClient creates a new ListProductsParams object
 // with the default values initialized, and the ability to set a custom HTTPClient for a request
 func NewListProductsParamsWithHTTPClient(client *http.Client) *ListProductsParams {
-
+	var ()
 	return &ListProductsParams{
 		HTTPClient: client,
 	}
@@ -59,6 +60,59 @@ func NewListProductsParamsWithHTTPClient(client *http.Client) *ListProductsParam
 for the list products operation typically these are written to a http.Request
 */
 type ListProductsParams struct {
+
+	/*AsOf
+	  Date in the format YYYY-MM-DD used to price the products with the
+	exchange rates published on that day, when not specified the latest
+	rates are used. Only applies when a currency is specified
+
+	*/
+	AsOf *strfmt.Date
+	/*Currency
+	  ISO 4217 code of the currency used when returning the price of the
+	product, e.g. USD. When not specified the price is returned in EUR
+
+	*/
+	Currency *string
+	/*Cursor
+	  Cursor for the page to return, taken from the Link header of the previous page
+
+	*/
+	Cursor *string
+	/*Limit
+	  Maximum number of products to return, at most 100.
+	when not specified all products are returned
+
+	*/
+	Limit *int64
+	/*MaxPrice
+	  Only return products with a price less than or equal to this value,
+	in the requested currency
+
+	*/
+	MaxPrice *float64
+	/*MinPrice
+	  Only return products with a price greater than or equal to this value,
+	in the requested currency
+
+	*/
+	MinPrice *float64
+	/*Name
+	  Only return products whose name contains this value, ignoring case
+
+	*/
+	NameContains *string
+	/*Sku
+	  Only return the product with this SKU
+
+	*/
+	SKU *string
+	/*Sort
+	  Field to sort the products by, prefix with - for descending order
+
+	*/
+	Sort *string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -97,6 +151,105 @@ func (o *ListProductsParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithAsOf adds the asOf to the list products params
+func (o *ListProductsParams) WithAsOf(asOf *strfmt.Date) *ListProductsParams {
+	o.SetAsOf(asOf)
+	return o
+}
+
+// SetAsOf adds the asOf to the list products params
+func (o *ListProductsParams) SetAsOf(asOf *strfmt.Date) {
+	o.AsOf = asOf
+}
+
+// WithCurrency adds the currency to the list products params
+func (o *ListProductsParams) WithCurrency(currency *string) *ListProductsParams {
+	o.SetCurrency(currency)
+	return o
+}
+
+// SetCurrency adds the currency to the list products params
+func (o *ListProductsParams) SetCurrency(currency *string) {
+	o.Currency = currency
+}
+
+// WithCursor adds the cursor to the list products params
+func (o *ListProductsParams) WithCursor(cursor *string) *ListProductsParams {
+	o.SetCursor(cursor)
+	return o
+}
+
+// SetCursor adds the cursor to the list products params
+func (o *ListProductsParams) SetCursor(cursor *string) {
+	o.Cursor = cursor
+}
+
+// WithLimit adds the limit to the list products params
+func (o *ListProductsParams) WithLimit(limit *int64) *ListProductsParams {
+	o.SetLimit(limit)
+	return o
+}
+
+// SetLimit adds the limit to the list products params
+func (o *ListProductsParams) SetLimit(limit *int64) {
+	o.Limit = limit
+}
+
+// WithMaxPrice adds the maxPrice to the list products params
+func (o *ListProductsParams) WithMaxPrice(maxPrice *float64) *ListProductsParams {
+	o.SetMaxPrice(maxPrice)
+	return o
+}
+
+// SetMaxPrice adds the maxPrice to the list products params
+func (o *ListProductsParams) SetMaxPrice(maxPrice *float64) {
+	o.MaxPrice = maxPrice
+}
+
+// WithMinPrice adds the minPrice to the list products params
+func (o *ListProductsParams) WithMinPrice(minPrice *float64) *ListProductsParams {
+	o.SetMinPrice(minPrice)
+	return o
+}
+
+// SetMinPrice adds the minPrice to the list products params
+func (o *ListProductsParams) SetMinPrice(minPrice *float64) {
+	o.MinPrice = minPrice
+}
+
+// WithNameContains adds the name to the list products params
+func (o *ListProductsParams) WithNameContains(name *string) *ListProductsParams {
+	o.SetNameContains(name)
+	return o
+}
+
+// SetNameContains adds the name to the list products params
+func (o *ListProductsParams) SetNameContains(name *string) {
+	o.NameContains = name
+}
+
+// WithSKU adds the sku to the list products params
+func (o *ListProductsParams) WithSKU(sku *string) *ListProductsParams {
+	o.SetSKU(sku)
+	return o
+}
+
+// SetSKU adds the sku to the list products params
+func (o *ListProductsParams) SetSKU(sku *string) {
+	o.SKU = sku
+}
+
+// WithSort adds the sort to the list products params
+func (o *ListProductsParams) WithSort(sort *string) *ListProductsParams {
+	o.SetSort(sort)
+	return o
+}
+
+// SetSort adds the sort to the list products params
+func (o *ListProductsParams) SetSort(sort *string) {
+	o.Sort = sort
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListProductsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -105,6 +258,150 @@ func (o *ListProductsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 	}
 	var res []error
 
+	if o.AsOf != nil {
+
+		// query param as_of
+		var qrAsOf strfmt.Date
+		if o.AsOf != nil {
+			qrAsOf = *o.AsOf
+		}
+		qAsOf := qrAsOf.String()
+		if qAsOf != "" {
+			if err := r.SetQueryParam("as_of", qAsOf); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Currency != nil {
+
+		// query param currency
+		var qrCurrency string
+		if o.Currency != nil {
+			qrCurrency = *o.Currency
+		}
+		qCurrency := qrCurrency
+		if qCurrency != "" {
+			if err := r.SetQueryParam("currency", qCurrency); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Cursor != nil {
+
+		// query param cursor
+		var qrCursor string
+		if o.Cursor != nil {
+			qrCursor = *o.Cursor
+		}
+		qCursor := qrCursor
+		if qCursor != "" {
+			if err := r.SetQueryParam("cursor", qCursor); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Limit != nil {
+
+		// query param limit
+		var qrLimit int64
+		if o.Limit != nil {
+			qrLimit = *o.Limit
+		}
+		qLimit := swag.FormatInt64(qrLimit)
+		if qLimit != "" {
+			if err := r.SetQueryParam("limit", qLimit); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.MaxPrice != nil {
+
+		// query param max_price
+		var qrMaxPrice float64
+		if o.MaxPrice != nil {
+			qrMaxPrice = *o.MaxPrice
+		}
+		qMaxPrice := swag.FormatFloat64(qrMaxPrice)
+		if qMaxPrice != "" {
+			if err := r.SetQueryParam("max_price", qMaxPrice); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.MinPrice != nil {
+
+		// query param min_price
+		var qrMinPrice float64
+		if o.MinPrice != nil {
+			qrMinPrice = *o.MinPrice
+		}
+		qMinPrice := swag.FormatFloat64(qrMinPrice)
+		if qMinPrice != "" {
+			if err := r.SetQueryParam("min_price", qMinPrice); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.NameContains != nil {
+
+		// query param name~
+		var qrName string
+		if o.NameContains != nil {
+			qrName = *o.NameContains
+		}
+		qName := qrName
+		if qName != "" {
+			if err := r.SetQueryParam("name~", qName); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.SKU != nil {
+
+		// query param sku
+		var qrSku string
+		if o.SKU != nil {
+			qrSku = *o.SKU
+		}
+		qSku := qrSku
+		if qSku != "" {
+			if err := r.SetQueryParam("sku", qSku); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Sort != nil {
+
+		// query param sort
+		var qrSort string
+		if o.Sort != nil {
+			qrSort = *o.Sort
+		}
+		qSort := qrSort
+		if qSort != "" {
+			if err := r.SetQueryParam("sort", qSort); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -61,6 +61,19 @@ for the list single product operation typically these are written to a http.Requ
 */
 type ListSingleProductParams struct {
 
+	/*AsOf
+	  Date in the format YYYY-MM-DD used to price the products with the
+	exchange rates published on that day, when not specified the latest
+	rates are used. Only applies when a currency is specified
+
+	*/
+	AsOf *strfmt.Date
+	/*Currency
+	  ISO 4217 code of the currency used when returning the price of the
+	product, e.g. USD. When not specified the price is returned in EUR
+
+	*/
+	Currency *string
 	/*ID
 	  The id of the product for which the operation relates
 
@@ -105,6 +118,28 @@ func (o *ListSingleProductParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithAsOf adds the asOf to the list single product params
+func (o *ListSingleProductParams) WithAsOf(asOf *strfmt.Date) *ListSingleProductParams {
+	o.SetAsOf(asOf)
+	return o
+}
+
+// SetAsOf adds the asOf to the list single product params
+func (o *ListSingleProductParams) SetAsOf(asOf *strfmt.Date) {
+	o.AsOf = asOf
+}
+
+// WithCurrency adds the currency to the list single product params
+func (o *ListSingleProductParams) WithCurrency(currency *string) *ListSingleProductParams {
+	o.SetCurrency(currency)
+	return o
+}
+
+// SetCurrency adds the currency to the list single product params
+func (o *ListSingleProductParams) SetCurrency(currency *string) {
+	o.Currency = currency
+}
+
 // WithID adds the id to the list single product params
 func (o *ListSingleProductParams) WithID(id int64) *ListSingleProductParams {
 	o.SetID(id)
@@ -124,6 +159,38 @@ func (o *ListSingleProductParams) WriteToRequest(r runtime.ClientRequest, reg st
 	}
 	var res []error
 
+	if o.AsOf != nil {
+
+		// query param as_of
+		var qrAsOf strfmt.Date
+		if o.AsOf != nil {
+			qrAsOf = *o.AsOf
+		}
+		qAsOf := qrAsOf.String()
+		if qAsOf != "" {
+			if err := r.SetQueryParam("as_of", qAsOf); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Currency != nil {
+
+		// query param currency
+		var qrCurrency string
+		if o.Currency != nil {
+			qrCurrency = *o.Currency
+		}
+		qCurrency := qrCurrency
+		if qCurrency != "" {
+			if err := r.SetQueryParam("currency", qCurrency); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// path param id
 	if err := r.SetPathParam("id", swag.FormatInt64(o.ID)); err != nil {
 		return err
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
-	"github/JamieBShaw/golang-mux-rest-api/models"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
 )
 
 // ListSingleProductReader is a Reader for the ListSingleProduct structure.
@@ -29,12 +31,24 @@ func (o *ListSingleProductReader) ReadResponse(response runtime.ClientResponse,
 			return nil, err
 		}
 		return result, nil
+	case 400:
+		result := NewListSingleProductBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 404:
 		result := NewListSingleProductNotFound()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
 		return nil, result
+	case 429:
+		result := NewListSingleProductTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
@@ -51,6 +65,22 @@ func NewListSingleProductOK() *ListSingleProductOK {
 A list of products
 */
 type ListSingleProductOK struct {
+	/*Link to the next page of products, only set when there are more results
+	 */
+	Link string
+	/*Snapshot of the exchange rates on the currency service the rate was
+	taken from, only set when a currency is specified and as_of is not
+	*/
+	XRateSnapshot string
+	/*Time the exchange rate used to convert the prices was updated, only set
+	when a currency is specified. For historical rates the date the rates
+	were published
+	*/
+	XRateTimestamp strfmt.DateTime
+	/*Total number of products matching the query across all pages
+	 */
+	XTotalCount int64
+
 	Payload []*models.Product
 }
 
@@ -64,6 +94,27 @@ func (o *ListSingleProductOK) GetPayload() []*models.Product {
 
 func (o *ListSingleProductOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// response header Link
+	o.Link = response.GetHeader("Link")
+
+	// response header X-Rate-Snapshot
+	o.XRateSnapshot = response.GetHeader("X-Rate-Snapshot")
+
+	// response header X-Rate-Timestamp
+
+	xRateTimestamp, err := formats.Parse("date-time", response.GetHeader("X-Rate-Timestamp"))
+	if err != nil {
+		return errors.InvalidType("X-Rate-Timestamp", "header", "strfmt.DateTime", response.GetHeader("X-Rate-Timestamp"))
+	}
+	o.XRateTimestamp = *(xRateTimestamp.(*strfmt.DateTime))
+
+	// response header X-Total-Count
+	xTotalCount, err := swag.ConvertInt64(response.GetHeader("X-Total-Count"))
+	if err != nil {
+		return errors.InvalidType("X-Total-Count", "header", "int64", response.GetHeader("X-Total-Count"))
+	}
+	o.XTotalCount = xTotalCount
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -72,6 +123,39 @@ func (o *ListSingleProductOK) readResponse(response runtime.ClientResponse, cons
 	return nil
 }
 
+// NewListSingleProductBadRequest creates a ListSingleProductBadRequest with default headers values
+func NewListSingleProductBadRequest() *ListSingleProductBadRequest {
+	return &ListSingleProductBadRequest{}
+}
+
+/*ListSingleProductBadRequest handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type ListSingleProductBadRequest struct {
+	Payload *models.GenericError
+}
+
+func (o *ListSingleProductBadRequest) Error() string {
+	return fmt.Sprintf("[GET /products/{id}][%d] listSingleProductBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ListSingleProductBadRequest) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ListSingleProductBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewListSingleProductNotFound creates a ListSingleProductNotFound with default headers values
 func NewListSingleProductNotFound() *ListSingleProductNotFound {
 	return &ListSingleProductNotFound{}
@@ -104,3 +188,78 @@ func (o *ListSingleProductNotFound) readResponse(response runtime.ClientResponse
 
 	return nil
 }
+
+// NewListSingleProductTooManyRequests creates a ListSingleProductTooManyRequests with default headers values
+func NewListSingleProductTooManyRequests() *ListSingleProductTooManyRequests {
+	return &ListSingleProductTooManyRequests{}
+}
+
+/*ListSingleProductTooManyRequests handles this case with default header values.
+
+The client has made too many requests, retry after the time in the
+Retry-After header
+*/
+type ListSingleProductTooManyRequests struct {
+	/*Number of requests the client can make in a burst
+	 */
+	RateLimitLimit int64
+	/*Number of requests the client can make now
+	 */
+	RateLimitRemaining int64
+	/*Seconds until the client can make a full burst of requests again
+	 */
+	RateLimitReset int64
+	/*Seconds until the client can make the next request
+	 */
+	RetryAfter int64
+
+	Payload *models.GenericError
+}
+
+func (o *ListSingleProductTooManyRequests) Error() string {
+	return fmt.Sprintf("[GET /products/{id}][%d] listSingleProductTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *ListSingleProductTooManyRequests) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ListSingleProductTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header RateLimit-Limit
+	rateLimitLimit, err := swag.ConvertInt64(response.GetHeader("RateLimit-Limit"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Limit", "header", "int64", response.GetHeader("RateLimit-Limit"))
+	}
+	o.RateLimitLimit = rateLimitLimit
+
+	// response header RateLimit-Remaining
+	rateLimitRemaining, err := swag.ConvertInt64(response.GetHeader("RateLimit-Remaining"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Remaining", "header", "int64", response.GetHeader("RateLimit-Remaining"))
+	}
+	o.RateLimitRemaining = rateLimitRemaining
+
+	// response header RateLimit-Reset
+	rateLimitReset, err := swag.ConvertInt64(response.GetHeader("RateLimit-Reset"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Reset", "header", "int64", response.GetHeader("RateLimit-Reset"))
+	}
+	o.RateLimitReset = rateLimitReset
+
+	// response header Retry-After
+	retryAfter, err := swag.ConvertInt64(response.GetHeader("Retry-After"))
+	if err != nil {
+		return errors.InvalidType("Retry-After", "header", "int64", response.GetHeader("Retry-After"))
+	}
+	o.RetryAfter = retryAfter
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -27,23 +27,27 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
-	CreateProduct(params *CreateProductParams) (*CreateProductOK, error)
+	CreateProduct(params *CreateProductParams, authInfo runtime.ClientAuthInfoWriter) (*CreateProductOK, error)
 
-	DeleteProduct(params *DeleteProductParams) (*DeleteProductCreated, error)
+	DeleteProduct(params *DeleteProductParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteProductCreated, error)
 
 	ListProducts(params *ListProductsParams) (*ListProductsOK, error)
 
 	ListSingleProduct(params *ListSingleProductParams) (*ListSingleProductOK, error)
 
-	UpdateProduct(params *UpdateProductParams) (*UpdateProductCreated, error)
+	SearchProducts(params *SearchProductsParams) (*SearchProductsOK, error)
+
+	UpdateProduct(params *UpdateProductParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateProductCreated, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
 /*
-  CreateProduct Create a new product
+CreateProduct creates a new product
+
+Requires the products:write scope
 */
-func (a *Client) CreateProduct(params *CreateProductParams) (*CreateProductOK, error) {
+func (a *Client) CreateProduct(params *CreateProductParams, authInfo runtime.ClientAuthInfoWriter) (*CreateProductOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
 		params = NewCreateProductParams()
@@ -58,6 +62,7 @@ func (a *Client) CreateProduct(params *CreateProductParams) (*CreateProductOK, e
 		Schemes:            []string{"http"},
 		Params:             params,
 		Reader:             &CreateProductReader{formats: a.formats},
+		AuthInfo:           authInfo,
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
@@ -75,9 +80,11 @@ func (a *Client) CreateProduct(params *CreateProductParams) (*CreateProductOK, e
 }
 
 /*
-  DeleteProduct Update a products details
+DeleteProduct deletes a product
+
+Requires the products:write scope
 */
-func (a *Client) DeleteProduct(params *DeleteProductParams) (*DeleteProductCreated, error) {
+func (a *Client) DeleteProduct(params *DeleteProductParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteProductCreated, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
 		params = NewDeleteProductParams()
@@ -92,6 +99,7 @@ func (a *Client) DeleteProduct(params *DeleteProductParams) (*DeleteProductCreat
 		Schemes:            []string{"http"},
 		Params:             params,
 		Reader:             &DeleteProductReader{formats: a.formats},
+		AuthInfo:           authInfo,
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
@@ -109,7 +117,10 @@ func (a *Client) DeleteProduct(params *DeleteProductParams) (*DeleteProductCreat
 }
 
 /*
-  ListProducts Returns a list of products from the database
+	ListProducts Returns a page of products from the database, filtered and sorted by the query
+
+parameters. When more products are available the Link header contains the
+URL of the next page
 */
 func (a *Client) ListProducts(params *ListProductsParams) (*ListProductsOK, error) {
 	// TODO: Validate the params before sending
@@ -143,7 +154,7 @@ func (a *Client) ListProducts(params *ListProductsParams) (*ListProductsOK, erro
 }
 
 /*
-  ListSingleProduct Returns a single product from the database
+ListSingleProduct Returns a single product from the database
 */
 func (a *Client) ListSingleProduct(params *ListSingleProductParams) (*ListSingleProductOK, error) {
 	// TODO: Validate the params before sending
@@ -177,9 +188,47 @@ func (a *Client) ListSingleProduct(params *ListSingleProductParams) (*ListSingle
 }
 
 /*
-  UpdateProduct Update a products details
+	SearchProducts Returns the products whose name or description match the query, most relevant
+
+first. Terms match whole words, prefixes of words and words with small typos
+*/
+func (a *Client) SearchProducts(params *SearchProductsParams) (*SearchProductsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSearchProductsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "searchProducts",
+		Method:             "GET",
+		PathPattern:        "/products/search",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &SearchProductsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SearchProductsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for searchProducts: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+UpdateProduct updates a products details
+
+Requires the products:write scope
 */
-func (a *Client) UpdateProduct(params *UpdateProductParams) (*UpdateProductCreated, error) {
+func (a *Client) UpdateProduct(params *UpdateProductParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateProductCreated, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
 		params = NewUpdateProductParams()
@@ -194,6 +243,7 @@ func (a *Client) UpdateProduct(params *UpdateProductParams) (*UpdateProductCreat
 		Schemes:            []string{"http"},
 		Params:             params,
 		Reader:             &UpdateProductReader{formats: a.formats},
+		AuthInfo:           authInfo,
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
@@ -0,0 +1,206 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package products
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewSearchProductsParams creates a new SearchProductsParams object
+// with the default values initialized.
+func NewSearchProductsParams() *SearchProductsParams {
+	var ()
+	return &SearchProductsParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewSearchProductsParamsWithTimeout creates a new SearchProductsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewSearchProductsParamsWithTimeout(timeout time.Duration) *SearchProductsParams {
+	var ()
+	return &SearchProductsParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewSearchProductsParamsWithContext creates a new SearchProductsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewSearchProductsParamsWithContext(ctx context.Context) *SearchProductsParams {
+	var ()
+	return &SearchProductsParams{
+
+		Context: ctx,
+	}
+}
+
+// NewSearchProductsParamsWithHTTPClient creates a new SearchProductsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewSearchProductsParamsWithHTTPClient(client *http.Client) *SearchProductsParams {
+	var ()
+	return &SearchProductsParams{
+		HTTPClient: client,
+	}
+}
+
+/*SearchProductsParams contains all the parameters to send to the API endpoint
+for the search products operation typically these are written to a http.Request
+*/
+type SearchProductsParams struct {
+
+	/*AsOf
+	  Date in the format YYYY-MM-DD used to price the products with the
+	exchange rates published on that day, when not specified the latest
+	rates are used. Only applies when a currency is specified
+
+	*/
+	AsOf *strfmt.Date
+	/*Currency
+	  ISO 4217 code of the currency used when returning the price of the
+	product, e.g. USD. When not specified the price is returned in EUR
+
+	*/
+	Currency *string
+	/*Q
+	  Words to search for in the product name and description
+
+	*/
+	Q string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the search products params
+func (o *SearchProductsParams) WithTimeout(timeout time.Duration) *SearchProductsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the search products params
+func (o *SearchProductsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the search products params
+func (o *SearchProductsParams) WithContext(ctx context.Context) *SearchProductsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the search products params
+func (o *SearchProductsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the search products params
+func (o *SearchProductsParams) WithHTTPClient(client *http.Client) *SearchProductsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the search products params
+func (o *SearchProductsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithAsOf adds the asOf to the search products params
+func (o *SearchProductsParams) WithAsOf(asOf *strfmt.Date) *SearchProductsParams {
+	o.SetAsOf(asOf)
+	return o
+}
+
+// SetAsOf adds the asOf to the search products params
+func (o *SearchProductsParams) SetAsOf(asOf *strfmt.Date) {
+	o.AsOf = asOf
+}
+
+// WithCurrency adds the currency to the search products params
+func (o *SearchProductsParams) WithCurrency(currency *string) *SearchProductsParams {
+	o.SetCurrency(currency)
+	return o
+}
+
+// SetCurrency adds the currency to the search products params
+func (o *SearchProductsParams) SetCurrency(currency *string) {
+	o.Currency = currency
+}
+
+// WithQ adds the q to the search products params
+func (o *SearchProductsParams) WithQ(q string) *SearchProductsParams {
+	o.SetQ(q)
+	return o
+}
+
+// SetQ adds the q to the search products params
+func (o *SearchProductsParams) SetQ(q string) {
+	o.Q = q
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *SearchProductsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.AsOf != nil {
+
+		// query param as_of
+		var qrAsOf strfmt.Date
+		if o.AsOf != nil {
+			qrAsOf = *o.AsOf
+		}
+		qAsOf := qrAsOf.String()
+		if qAsOf != "" {
+			if err := r.SetQueryParam("as_of", qAsOf); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Currency != nil {
+
+		// query param currency
+		var qrCurrency string
+		if o.Currency != nil {
+			qrCurrency = *o.Currency
+		}
+		qCurrency := qrCurrency
+		if qCurrency != "" {
+			if err := r.SetQueryParam("currency", qCurrency); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	// query param q
+	qrQ := o.Q
+	qQ := qrQ
+	if qQ != "" {
+		if err := r.SetQueryParam("q", qQ); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package products
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
+)
+
+// SearchProductsReader is a Reader for the SearchProducts structure.
+type SearchProductsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *SearchProductsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewSearchProductsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewSearchProductsBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 429:
+		result := NewSearchProductsTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewSearchProductsOK creates a SearchProductsOK with default headers values
+func NewSearchProductsOK() *SearchProductsOK {
+	return &SearchProductsOK{}
+}
+
+/*SearchProductsOK handles this case with default header values.
+
+Products matching a search ordered by relevance
+*/
+type SearchProductsOK struct {
+	/*Snapshot of the exchange rates on the currency service the rate was
+	taken from, only set when a currency is specified and as_of is not
+	*/
+	XRateSnapshot string
+	/*Time the exchange rate used to convert the prices was updated, only set
+	when a currency is specified. For historical rates the date the rates
+	were published
+	*/
+	XRateTimestamp strfmt.DateTime
+
+	Payload []*models.SearchResult
+}
+
+func (o *SearchProductsOK) Error() string {
+	return fmt.Sprintf("[GET /products/search][%d] searchProductsOK  %+v", 200, o.Payload)
+}
+
+func (o *SearchProductsOK) GetPayload() []*models.SearchResult {
+	return o.Payload
+}
+
+func (o *SearchProductsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header X-Rate-Snapshot
+	o.XRateSnapshot = response.GetHeader("X-Rate-Snapshot")
+
+	// response header X-Rate-Timestamp
+
+	xRateTimestamp, err := formats.Parse("date-time", response.GetHeader("X-Rate-Timestamp"))
+	if err != nil {
+		return errors.InvalidType("X-Rate-Timestamp", "header", "strfmt.DateTime", response.GetHeader("X-Rate-Timestamp"))
+	}
+	o.XRateTimestamp = *(xRateTimestamp.(*strfmt.DateTime))
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewSearchProductsBadRequest creates a SearchProductsBadRequest with default headers values
+func NewSearchProductsBadRequest() *SearchProductsBadRequest {
+	return &SearchProductsBadRequest{}
+}
+
+/*SearchProductsBadRequest handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type SearchProductsBadRequest struct {
+	Payload *models.GenericError
+}
+
+func (o *SearchProductsBadRequest) Error() string {
+	return fmt.Sprintf("[GET /products/search][%d] searchProductsBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *SearchProductsBadRequest) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *SearchProductsBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewSearchProductsTooManyRequests creates a SearchProductsTooManyRequests with default headers values
+func NewSearchProductsTooManyRequests() *SearchProductsTooManyRequests {
+	return &SearchProductsTooManyRequests{}
+}
+
+/*SearchProductsTooManyRequests handles this case with default header values.
+
+The client has made too many requests, retry after the time in the
+Retry-After header
+*/
+type SearchProductsTooManyRequests struct {
+	/*Number of requests the client can make in a burst
+	 */
+	RateLimitLimit int64
+	/*Number of requests the client can make now
+	 */
+	RateLimitRemaining int64
+	/*Seconds until the client can make a full burst of requests again
+	 */
+	RateLimitReset int64
+	/*Seconds until the client can make the next request
+	 */
+	RetryAfter int64
+
+	Payload *models.GenericError
+}
+
+func (o *SearchProductsTooManyRequests) Error() string {
+	return fmt.Sprintf("[GET /products/search][%d] searchProductsTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *SearchProductsTooManyRequests) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *SearchProductsTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header RateLimit-Limit
+	rateLimitLimit, err := swag.ConvertInt64(response.GetHeader("RateLimit-Limit"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Limit", "header", "int64", response.GetHeader("RateLimit-Limit"))
+	}
+	o.RateLimitLimit = rateLimitLimit
+
+	// response header RateLimit-Remaining
+	rateLimitRemaining, err := swag.ConvertInt64(response.GetHeader("RateLimit-Remaining"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Remaining", "header", "int64", response.GetHeader("RateLimit-Remaining"))
+	}
+	o.RateLimitRemaining = rateLimitRemaining
+
+	// response header RateLimit-Reset
+	rateLimitReset, err := swag.ConvertInt64(response.GetHeader("RateLimit-Reset"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Reset", "header", "int64", response.GetHeader("RateLimit-Reset"))
+	}
+	o.RateLimitReset = rateLimitReset
+
+	// response header Retry-After
+	retryAfter, err := swag.ConvertInt64(response.GetHeader("Retry-After"))
+	if err != nil {
+		return errors.InvalidType("Retry-After", "header", "int64", response.GetHeader("Retry-After"))
+	}
+	o.RetryAfter = retryAfter
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
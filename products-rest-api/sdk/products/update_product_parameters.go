@@ -15,7 +15,7 @@ import (
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
-	"github/JamieBShaw/golang-mux-rest-api/models"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
 )
 
 // NewUpdateProductParams creates a new UpdateProductParams object
@@ -65,9 +65,17 @@ type UpdateProductParams struct {
 	/*Body
 	  Product data structure to Update or Create.
 	Note: the id field is ignored by update and create operations
+	and the version field is ignored, use the If-Match header instead
 
 	*/
 	Body *models.Product
+	/*IfMatch
+	  ETag of the product version the update is based on, as returned by
+	GET /products/{id}. When the product has since been modified the
+	update is rejected with 409 Conflict
+
+	*/
+	IfMatch *string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -118,6 +126,17 @@ func (o *UpdateProductParams) SetBody(body *models.Product) {
 	o.Body = body
 }
 
+// WithIfMatch adds the ifMatch to the update product params
+func (o *UpdateProductParams) WithIfMatch(ifMatch *string) *UpdateProductParams {
+	o.SetIfMatch(ifMatch)
+	return o
+}
+
+// SetIfMatch adds the ifMatch to the update product params
+func (o *UpdateProductParams) SetIfMatch(ifMatch *string) {
+	o.IfMatch = ifMatch
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *UpdateProductParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -132,6 +151,15 @@ func (o *UpdateProductParams) WriteToRequest(r runtime.ClientRequest, reg strfmt
 		}
 	}
 
+	if o.IfMatch != nil {
+
+		// header param If-Match
+		if err := r.SetHeaderParam("If-Match", *o.IfMatch); err != nil {
+			return err
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
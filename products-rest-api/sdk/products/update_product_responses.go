@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
-	"github/JamieBShaw/golang-mux-rest-api/models"
+	"github.com/JamieBShaw/golang-mux-rest-api/products-rest-api/sdk/models"
 )
 
 // UpdateProductReader is a Reader for the UpdateProduct structure.
@@ -29,18 +31,48 @@ func (o *UpdateProductReader) ReadResponse(response runtime.ClientResponse, cons
 			return nil, err
 		}
 		return result, nil
+	case 400:
+		result := NewUpdateProductBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 401:
+		result := NewUpdateProductUnauthorized()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 403:
+		result := NewUpdateProductForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 404:
 		result := NewUpdateProductNotFound()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
 		return nil, result
+	case 409:
+		result := NewUpdateProductConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 422:
 		result := NewUpdateProductUnprocessableEntity()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
 		return nil, result
+	case 429:
+		result := NewUpdateProductTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 
 	default:
 		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
@@ -68,6 +100,105 @@ func (o *UpdateProductCreated) readResponse(response runtime.ClientResponse, con
 	return nil
 }
 
+// NewUpdateProductBadRequest creates a UpdateProductBadRequest with default headers values
+func NewUpdateProductBadRequest() *UpdateProductBadRequest {
+	return &UpdateProductBadRequest{}
+}
+
+/*UpdateProductBadRequest handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type UpdateProductBadRequest struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateProductBadRequest) Error() string {
+	return fmt.Sprintf("[PUT /products][%d] updateProductBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *UpdateProductBadRequest) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateProductBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateProductUnauthorized creates a UpdateProductUnauthorized with default headers values
+func NewUpdateProductUnauthorized() *UpdateProductUnauthorized {
+	return &UpdateProductUnauthorized{}
+}
+
+/*UpdateProductUnauthorized handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type UpdateProductUnauthorized struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateProductUnauthorized) Error() string {
+	return fmt.Sprintf("[PUT /products][%d] updateProductUnauthorized  %+v", 401, o.Payload)
+}
+
+func (o *UpdateProductUnauthorized) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateProductUnauthorized) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateProductForbidden creates a UpdateProductForbidden with default headers values
+func NewUpdateProductForbidden() *UpdateProductForbidden {
+	return &UpdateProductForbidden{}
+}
+
+/*UpdateProductForbidden handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type UpdateProductForbidden struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateProductForbidden) Error() string {
+	return fmt.Sprintf("[PUT /products][%d] updateProductForbidden  %+v", 403, o.Payload)
+}
+
+func (o *UpdateProductForbidden) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateProductForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewUpdateProductNotFound creates a UpdateProductNotFound with default headers values
 func NewUpdateProductNotFound() *UpdateProductNotFound {
 	return &UpdateProductNotFound{}
@@ -101,6 +232,39 @@ func (o *UpdateProductNotFound) readResponse(response runtime.ClientResponse, co
 	return nil
 }
 
+// NewUpdateProductConflict creates a UpdateProductConflict with default headers values
+func NewUpdateProductConflict() *UpdateProductConflict {
+	return &UpdateProductConflict{}
+}
+
+/*UpdateProductConflict handles this case with default header values.
+
+Generic error message returned as a string
+*/
+type UpdateProductConflict struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateProductConflict) Error() string {
+	return fmt.Sprintf("[PUT /products][%d] updateProductConflict  %+v", 409, o.Payload)
+}
+
+func (o *UpdateProductConflict) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateProductConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewUpdateProductUnprocessableEntity creates a UpdateProductUnprocessableEntity with default headers values
 func NewUpdateProductUnprocessableEntity() *UpdateProductUnprocessableEntity {
 	return &UpdateProductUnprocessableEntity{}
@@ -133,3 +297,78 @@ func (o *UpdateProductUnprocessableEntity) readResponse(response runtime.ClientR
 
 	return nil
 }
+
+// NewUpdateProductTooManyRequests creates a UpdateProductTooManyRequests with default headers values
+func NewUpdateProductTooManyRequests() *UpdateProductTooManyRequests {
+	return &UpdateProductTooManyRequests{}
+}
+
+/*UpdateProductTooManyRequests handles this case with default header values.
+
+The client has made too many requests, retry after the time in the
+Retry-After header
+*/
+type UpdateProductTooManyRequests struct {
+	/*Number of requests the client can make in a burst
+	 */
+	RateLimitLimit int64
+	/*Number of requests the client can make now
+	 */
+	RateLimitRemaining int64
+	/*Seconds until the client can make a full burst of requests again
+	 */
+	RateLimitReset int64
+	/*Seconds until the client can make the next request
+	 */
+	RetryAfter int64
+
+	Payload *models.GenericError
+}
+
+func (o *UpdateProductTooManyRequests) Error() string {
+	return fmt.Sprintf("[PUT /products][%d] updateProductTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *UpdateProductTooManyRequests) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateProductTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header RateLimit-Limit
+	rateLimitLimit, err := swag.ConvertInt64(response.GetHeader("RateLimit-Limit"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Limit", "header", "int64", response.GetHeader("RateLimit-Limit"))
+	}
+	o.RateLimitLimit = rateLimitLimit
+
+	// response header RateLimit-Remaining
+	rateLimitRemaining, err := swag.ConvertInt64(response.GetHeader("RateLimit-Remaining"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Remaining", "header", "int64", response.GetHeader("RateLimit-Remaining"))
+	}
+	o.RateLimitRemaining = rateLimitRemaining
+
+	// response header RateLimit-Reset
+	rateLimitReset, err := swag.ConvertInt64(response.GetHeader("RateLimit-Reset"))
+	if err != nil {
+		return errors.InvalidType("RateLimit-Reset", "header", "int64", response.GetHeader("RateLimit-Reset"))
+	}
+	o.RateLimitReset = rateLimitReset
+
+	// response header Retry-After
+	retryAfter, err := swag.ConvertInt64(response.GetHeader("Retry-After"))
+	if err != nil {
+		return errors.InvalidType("Retry-After", "header", "int64", response.GetHeader("Retry-After"))
+	}
+	o.RetryAfter = retryAfter
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
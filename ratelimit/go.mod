@@ -0,0 +1,15 @@
+module github.com/JamieBShaw/golang-mux-rest-api/ratelimit
+
+go 1.14
+
+require (
+	github.com/JamieBShaw/golang-mux-rest-api/auth v0.0.0
+	github.com/JamieBShaw/golang-mux-rest-api/requestlog v0.0.0
+	github.com/gorilla/mux v1.8.0
+	github.com/hashicorp/go-hclog v0.14.1
+	github.com/stretchr/testify v1.6.1
+)
+
+replace github.com/JamieBShaw/golang-mux-rest-api/auth => ../auth
+
+replace github.com/JamieBShaw/golang-mux-rest-api/requestlog => ../requestlog
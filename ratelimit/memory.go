@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often full buckets are removed from a MemoryStore
+const sweepInterval = time.Minute
+
+// bucket is the state of a token bucket, tokens are added lazily from the time
+// of the last update
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// MemoryStore is a Store which keeps the buckets in memory, the limits are
+// not shared between instances of a service
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now, lastSweep: time.Now()}
+}
+
+// Take takes a token from the bucket with the key
+func (m *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: float64(limit.Requests), updated: now, limit: limit}
+		m.buckets[key] = b
+	}
+
+	b.refill(now)
+
+	res := Result{}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - b.tokens) * float64(limit.interval()))
+	}
+
+	res.Remaining = int(b.tokens)
+	res.Reset = time.Duration((float64(limit.Requests) - b.tokens) * float64(limit.interval()))
+
+	return res, nil
+}
+
+// Len returns the number of buckets in the store
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.buckets)
+}
+
+// refill adds the tokens for the time since the last update
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens += float64(elapsed) / float64(b.limit.interval())
+	if full := float64(b.limit.Requests); b.tokens > full {
+		b.tokens = full
+	}
+	b.updated = now
+}
+
+// sweep removes the buckets which have refilled, a new full bucket is created
+// the next time the client makes a request so this does not change the limits
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for k, b := range m.buckets {
+		if now.Sub(b.updated) >= b.limit.Per {
+			delete(m.buckets, k)
+		}
+	}
+}
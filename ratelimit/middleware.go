@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/auth"
+	"github.com/JamieBShaw/golang-mux-rest-api/requestlog"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+)
+
+// the headers describing the quota of the client, from the IETF RateLimit
+// header fields draft
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// ErrTooManyRequests is an error raised when a client has used its quota
+var ErrTooManyRequests = fmt.Errorf("Too many requests, retry later")
+
+// KeyFunc returns the key identifying the client making the request
+type KeyFunc func(r *http.Request) string
+
+// ClientKey identifies authenticated clients by the subject of their JWT or
+// the name of their API key, and other clients by their IP address. Rate limit
+// middleware must come after the auth middleware for the caller to be known,
+// unverified API keys are not used so clients can not reset their quota by
+// sending a new key
+func ClientKey(r *http.Request) string {
+	if p, ok := auth.FromContext(r.Context()); ok {
+		return "principal:" + p.Subject
+	}
+
+	return IPKey(r)
+}
+
+// IPKey identifies clients by their IP address whether or not they are
+// authenticated. Use it ahead of the auth middleware to limit the credentials
+// a client can try, rejected requests never reach middleware using ClientKey
+func IPKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// Limiter creates rate limit middleware sharing a Store
+type Limiter struct {
+	store Store
+	key   KeyFunc
+	log   hclog.Logger
+}
+
+// NewLimiter returns a Limiter keeping the buckets in the store, clients are
+// identified by the key function
+func NewLimiter(s Store, key KeyFunc, l hclog.Logger) *Limiter {
+	return &Limiter{store: s, key: key, log: l}
+}
+
+// Middleware returns middleware which limits the requests each client makes
+// to the routes it is used on. Routes using middleware with the same name
+// share the quota of the client. Requests are allowed when the limit is not
+// enabled or the store fails, so a failing shared store does not stop the
+// service
+func (lm *Limiter) Middleware(name string, limit Limit) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if !limit.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			l := requestlog.Logger(r.Context(), lm.log)
+			key := lm.key(r)
+
+			res, err := lm.store.Take(r.Context(), name+":"+key, limit)
+			if err != nil {
+				l.Error("Unable to check rate limit, allowing request", "limit", name, "error", err)
+
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			rw.Header().Set(HeaderLimit, strconv.Itoa(limit.Requests))
+			rw.Header().Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+			rw.Header().Set(HeaderReset, seconds(res.Reset))
+
+			if !res.Allowed {
+				l.Warn("Rate limit exceeded", "limit", name, "client", key, "retry_after", res.RetryAfter)
+
+				rw.Header().Set(HeaderRetryAfter, seconds(res.RetryAfter))
+				writeError(rw, http.StatusTooManyRequests, ErrTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// seconds returns the duration as whole seconds rounded up, so clients waiting
+// for the time do not retry too early
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// writeError writes the error as a JSON message in the same format as the
+// errors returned by the services
+func writeError(rw http.ResponseWriter, status int, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	json.NewEncoder(rw).Encode(&struct {
+		Message string `json:"message"`
+	}{err.Error()})
+}
@@ -0,0 +1,96 @@
+// Package ratelimit limits the rate of requests each client can make with a
+// token bucket per client and route.
+//
+// A bucket holds Limit.Requests tokens and is refilled evenly over Limit.Per,
+// every request takes a token and is rejected with 429 Too Many Requests when
+// the bucket is empty. Buckets are kept in a Store, MemoryStore keeps them in
+// the process and a shared Store lets instances of a service share the limits
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLimit is an error raised when a limit can not be parsed
+var ErrInvalidLimit = fmt.Errorf("Invalid rate limit, expected the format requests/duration e.g. 30/1m")
+
+// Limit is the number of requests a client can make in a period, the whole
+// quota can be used in a burst
+type Limit struct {
+	// Requests is the size of the bucket
+	Requests int
+
+	// Per is the time taken to refill an empty bucket
+	Per time.Duration
+}
+
+// ParseLimit parses a limit in the format requests/duration, e.g. 30/1m. An
+// empty string is a zero Limit which does not limit requests
+func ParseLimit(s string) (Limit, error) {
+	if s == "" {
+		return Limit{}, nil
+	}
+
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("%w: %s", ErrInvalidLimit, s)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("%w: %s", ErrInvalidLimit, s)
+	}
+
+	return Limit{Requests: n, Per: d}, nil
+}
+
+// Enabled returns true when requests are limited
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// String returns the limit in the format read by ParseLimit
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return ""
+	}
+
+	return fmt.Sprintf("%d/%s", l.Requests, l.Per)
+}
+
+// interval returns the time taken to add one token to the bucket
+func (l Limit) interval() time.Duration {
+	return l.Per / time.Duration(l.Requests)
+}
+
+// Result of taking a token from a bucket
+type Result struct {
+	// Allowed is true when a token was taken and the request can be handled
+	Allowed bool
+
+	// Remaining is the number of whole tokens left in the bucket
+	Remaining int
+
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+
+	// RetryAfter is the time until the next token is added when the request
+	// was not allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets of the clients
+type Store interface {
+	// Take takes a token from the bucket with the key, creating a full bucket
+	// for the limit when there is none
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,233 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JamieBShaw/golang-mux-rest-api/auth"
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is the time of a MemoryStore in tests
+type fakeClock struct {
+	t time.Time
+}
+
+func (f *fakeClock) now() time.Time {
+	return f.t
+}
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	c := &fakeClock{time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)}
+	m := NewMemoryStore()
+	m.now = c.now
+	m.lastSweep = c.t
+
+	return m, c
+}
+
+func TestParseLimit(t *testing.T) {
+	l, err := ParseLimit("30/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 30, Per: time.Minute}, l)
+	assert.Equal(t, "30/1m0s", l.String())
+
+	l, err = ParseLimit("")
+	assert.NoError(t, err)
+	assert.False(t, l.Enabled())
+
+	for _, s := range []string{"30", "0/1m", "-1/1m", "30/minute", "30/0s"} {
+		_, err := ParseLimit(s)
+		assert.True(t, errors.Is(err, ErrInvalidLimit), s)
+	}
+}
+
+func TestMemoryStoreTakesTokens(t *testing.T) {
+	m, c := newTestStore()
+	limit := Limit{Requests: 3, Per: 3 * time.Second}
+
+	for i := 2; i >= 0; i-- {
+		res, err := m.Take(context.Background(), "client", limit)
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, i, res.Remaining)
+	}
+
+	res, _ := m.Take(context.Background(), "client", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, time.Second, res.RetryAfter)
+	assert.Equal(t, 3*time.Second, res.Reset)
+
+	// other clients have their own bucket
+	res, _ = m.Take(context.Background(), "other", limit)
+	assert.True(t, res.Allowed)
+
+	// one token is added every second
+	c.t = c.t.Add(time.Second)
+	res, _ = m.Take(context.Background(), "client", limit)
+	assert.True(t, res.Allowed)
+	res, _ = m.Take(context.Background(), "client", limit)
+	assert.False(t, res.Allowed)
+
+	// the bucket does not fill beyond the limit
+	c.t = c.t.Add(time.Hour)
+	res, _ = m.Take(context.Background(), "client", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	m, c := newTestStore()
+	limit := Limit{Requests: 10, Per: time.Second}
+
+	m.Take(context.Background(), "idle", limit)
+	c.t = c.t.Add(sweepInterval)
+	m.Take(context.Background(), "active", limit)
+
+	assert.Equal(t, 1, m.Len())
+}
+
+// failingStore is a Store which is unavailable
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	return Result{}, fmt.Errorf("Store unavailable")
+}
+
+func newTestRouter(s Store) *mux.Router {
+	lm := NewLimiter(s, ClientKey, hclog.NewNullLogger())
+	ok := func(rw http.ResponseWriter, r *http.Request) {}
+
+	r := mux.NewRouter()
+	reads := r.Methods(http.MethodGet).Subrouter()
+	reads.HandleFunc("/products", ok)
+	reads.HandleFunc("/products/{id}", ok)
+	reads.Use(lm.Middleware("read", Limit{Requests: 2, Per: time.Minute}))
+
+	writes := r.Methods(http.MethodPost).Subrouter()
+	writes.HandleFunc("/products", ok)
+	writes.Use(lm.Middleware("write", Limit{Requests: 1, Per: time.Minute}))
+
+	return r
+}
+
+func request(h http.Handler, method, path, addr string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	r.RemoteAddr = addr
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	return rw
+}
+
+func TestMiddlewareLimitsClients(t *testing.T) {
+	m, _ := newTestStore()
+	h := newTestRouter(m)
+
+	rw := request(h, http.MethodGet, "/products", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "2", rw.Header().Get(HeaderLimit))
+	assert.Equal(t, "1", rw.Header().Get(HeaderRemaining))
+	assert.Equal(t, "30", rw.Header().Get(HeaderReset))
+
+	// routes on the same subrouter share the quota, the port is ignored
+	rw = request(h, http.MethodGet, "/products/1", "10.0.0.1:5678")
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	rw = request(h, http.MethodGet, "/products", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "0", rw.Header().Get(HeaderRemaining))
+	assert.Equal(t, "30", rw.Header().Get(HeaderRetryAfter))
+	assert.JSONEq(t, `{"message": "Too many requests, retry later"}`, rw.Body.String())
+
+	// writes have their own quota and other clients are not limited
+	assert.Equal(t, http.StatusOK, request(h, http.MethodPost, "/products", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(h, http.MethodPost, "/products", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, request(h, http.MethodGet, "/products", "10.0.0.2:1234").Code)
+}
+
+func TestMiddlewareAllowsRequestsWhenStoreFails(t *testing.T) {
+	h := newTestRouter(failingStore{})
+
+	for i := 0; i < 3; i++ {
+		rw := request(h, http.MethodPost, "/products", "10.0.0.1:1234")
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Empty(t, rw.Header().Get(HeaderLimit))
+	}
+}
+
+func TestDisabledLimitIsNotApplied(t *testing.T) {
+	m, _ := newTestStore()
+	lm := NewLimiter(m, ClientKey, hclog.NewNullLogger())
+	h := lm.Middleware("read", Limit{})(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+
+	rw := request(h, http.MethodGet, "/products", "10.0.0.1:1234")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, rw.Header().Get(HeaderLimit))
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMiddlewareKeysAuthenticatedClientsByPrincipal(t *testing.T) {
+	m, _ := newTestStore()
+	a := auth.NewAuthenticator(auth.Config{APIKeys: []auth.APIKey{
+		{Name: "admin", Key: "admin-key-0123456789", Scopes: []string{"products:write"}},
+	}}, hclog.NewNullLogger())
+	lm := NewLimiter(m, ClientKey, hclog.NewNullLogger())
+
+	r := mux.NewRouter()
+	r.HandleFunc("/products", func(rw http.ResponseWriter, r *http.Request) {})
+	r.Use(a.Require("products:write"), lm.Middleware("write", Limit{Requests: 1, Per: time.Minute}))
+
+	post := func(addr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/products", nil)
+		req.Header.Set(auth.HeaderAPIKey, "admin-key-0123456789")
+		req.RemoteAddr = addr
+		rw := httptest.NewRecorder()
+		r.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	// the quota follows the API key when the client changes address
+	assert.Equal(t, http.StatusOK, post("10.0.0.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, post("10.0.0.2:1234"))
+}
+
+func TestIPKeyLimitsRejectedCredentials(t *testing.T) {
+	m, _ := newTestStore()
+	a := auth.NewAuthenticator(auth.Config{APIKeys: []auth.APIKey{
+		{Name: "admin", Key: "admin-key-0123456789", Scopes: []string{"products:write"}},
+	}}, hclog.NewNullLogger())
+	ip := NewLimiter(m, IPKey, hclog.NewNullLogger())
+
+	r := mux.NewRouter()
+	r.HandleFunc("/products", func(rw http.ResponseWriter, r *http.Request) {})
+	r.Use(ip.Middleware("auth", Limit{Requests: 2, Per: time.Minute}), a.Require("products:write"))
+
+	post := func(key, addr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/products", nil)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		req.RemoteAddr = addr
+		rw := httptest.NewRecorder()
+		r.ServeHTTP(rw, req)
+
+		return rw.Code
+	}
+
+	// guessing keys uses the quota of the address
+	assert.Equal(t, http.StatusUnauthorized, post("guess-1", "10.0.0.1:1234"))
+	assert.Equal(t, http.StatusUnauthorized, post("guess-2", "10.0.0.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, post("guess-3", "10.0.0.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, post("admin-key-0123456789", "10.0.0.1:1234"))
+
+	// other addresses are not limited
+	assert.Equal(t, http.StatusOK, post("admin-key-0123456789", "10.0.0.2:1234"))
+}
@@ -0,0 +1,10 @@
+module github.com/JamieBShaw/golang-mux-rest-api/requestlog
+
+go 1.14
+
+require (
+	github.com/gorilla/mux v1.8.0
+	github.com/hashicorp/go-hclog v0.14.1
+	github.com/stretchr/testify v1.6.1
+	google.golang.org/grpc v1.31.0
+)
@@ -0,0 +1,105 @@
+package requestlog
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Middleware returns middleware which takes the request ID from the
+// X-Request-ID header, or generates one when it is missing or invalid, and
+// returns it in the response. One access log line is written to l for every
+// request, it must be used on the router so the route is known
+func Middleware(l hclog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(HeaderRequestID)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			rw.Header().Set(HeaderRequestID, id)
+
+			ctx := WithRequestID(r.Context(), id, l)
+			body := &countingBody{ReadCloser: r.Body}
+			r = r.WithContext(ctx)
+			r.Body = body
+
+			rr := NewResponseRecorder(rw)
+			next.ServeHTTP(rr, r)
+
+			Logger(ctx, l).Info(
+				"Handled request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"route", RouteTemplate(r),
+				"status", rr.Status,
+				"duration", time.Since(start),
+				"bytes_in", body.n,
+				"bytes_out", rr.Bytes,
+			)
+		})
+	}
+}
+
+// RouteTemplate returns the path template of the route matching the request,
+// unknown when no route matched. Use it instead of the path to label requests
+// so the ids in paths do not create a label value per id
+func RouteTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "unknown"
+	}
+
+	t, err := route.GetPathTemplate()
+	if err != nil {
+		return "unknown"
+	}
+
+	return t
+}
+
+// ResponseRecorder records the status code and the number of bytes written
+// to the response
+type ResponseRecorder struct {
+	http.ResponseWriter
+
+	// Status is the status code of the response
+	Status int
+
+	// Bytes is the number of bytes written to the body
+	Bytes int
+}
+
+// NewResponseRecorder returns a ResponseRecorder writing to rw, the status is
+// 200 until another status is written
+func NewResponseRecorder(rw http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: rw, Status: http.StatusOK}
+}
+
+func (rr *ResponseRecorder) WriteHeader(code int) {
+	rr.Status = code
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+func (rr *ResponseRecorder) Write(b []byte) (int, error) {
+	n, err := rr.ResponseWriter.Write(b)
+	rr.Bytes += n
+	return n, err
+}
+
+// countingBody counts the bytes read from the request body
+type countingBody struct {
+	io.ReadCloser
+	n int
+}
+
+func (c *countingBody) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += n
+	return n, err
+}
@@ -0,0 +1,77 @@
+// Package requestlog identifies each HTTP request with a request ID, attaches a
+// logger for the request to its context and writes an access log line once
+// the request has been handled. The request ID is passed on to the currency
+// service as gRPC metadata
+package requestlog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// HeaderRequestID is the HTTP header used to receive and return the request ID
+const HeaderRequestID = "X-Request-ID"
+
+// MetadataRequestID is the gRPC metadata key the request ID is sent with
+const MetadataRequestID = "x-request-id"
+
+// maxRequestIDLength is the longest request ID accepted from a client
+const maxRequestIDLength = 128
+
+type keyRequestID struct{}
+type keyLogger struct{}
+
+// RequestID returns the ID of the request the context belongs to, empty when
+// the context is not for a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(keyRequestID{}).(string)
+	return id
+}
+
+// Logger returns the logger for the request the context belongs to, which
+// logs the request ID with every message. When the context is not for a
+// request l is returned
+func Logger(ctx context.Context, l hclog.Logger) hclog.Logger {
+	if rl, ok := ctx.Value(keyLogger{}).(hclog.Logger); ok {
+		return rl
+	}
+
+	return l
+}
+
+// WithRequestID returns a copy of the context for the request with the given
+// ID, the request logger is created from l
+func WithRequestID(ctx context.Context, id string, l hclog.Logger) context.Context {
+	ctx = context.WithValue(ctx, keyRequestID{}, id)
+	return context.WithValue(ctx, keyLogger{}, l.With("request_id", id))
+}
+
+// newRequestID returns a random 128 bit ID encoded as hex
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// should never happen, crypto/rand does not fail on supported platforms
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// validRequestID returns true when a request ID from a client is safe to log
+// and forward, it must be printable ASCII without spaces
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,115 @@
+package requestlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// newTestRouter returns a router using the middleware with a JSON logger
+// writing to the buffer, the handler echoes the request body and logs a message
+func newTestRouter(buf *bytes.Buffer) *mux.Router {
+	l := hclog.New(&hclog.LoggerOptions{Output: buf, JSONFormat: true})
+
+	r := mux.NewRouter()
+	r.Use(Middleware(l))
+	r.HandleFunc("/products/{id:[0-9]+}", func(rw http.ResponseWriter, r *http.Request) {
+		Logger(r.Context(), hclog.NewNullLogger()).Info("Handling request")
+
+		b, _ := ioutil.ReadAll(r.Body)
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write(b)
+	})
+
+	return r
+}
+
+// logLines decodes the JSON log lines in the buffer
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	lines := []map[string]interface{}{}
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		m := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(l), &m))
+		lines = append(lines, m)
+	}
+
+	return lines
+}
+
+func TestMiddlewareLogsRequest(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/products/1", strings.NewReader("Hello World"))
+
+	newTestRouter(buf).ServeHTTP(rw, r)
+
+	id := rw.Header().Get(HeaderRequestID)
+	assert.Len(t, id, 32)
+
+	lines := logLines(t, buf)
+	assert.Len(t, lines, 2)
+
+	// the handler logs with the request id
+	assert.Equal(t, "Handling request", lines[0]["@message"])
+	assert.Equal(t, id, lines[0]["request_id"])
+
+	access := lines[1]
+	assert.Equal(t, id, access["request_id"])
+	assert.Equal(t, "POST", access["method"])
+	assert.Equal(t, "/products/{id:[0-9]+}", access["route"])
+	assert.Equal(t, float64(http.StatusCreated), access["status"])
+	assert.Equal(t, float64(11), access["bytes_in"])
+	assert.Equal(t, float64(11), access["bytes_out"])
+	assert.Contains(t, access, "duration")
+}
+
+func TestMiddlewarePropagatesRequestID(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+	r.Header.Set(HeaderRequestID, "abc-123")
+
+	newTestRouter(buf).ServeHTTP(rw, r)
+
+	assert.Equal(t, "abc-123", rw.Header().Get(HeaderRequestID))
+	assert.Equal(t, "abc-123", logLines(t, buf)[1]["request_id"])
+}
+
+func TestMiddlewareReplacesInvalidRequestID(t *testing.T) {
+	for _, id := range []string{"has space", "new\nline", strings.Repeat("a", 129)} {
+		rw := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+		r.Header.Set(HeaderRequestID, id)
+
+		newTestRouter(&bytes.Buffer{}).ServeHTTP(rw, r)
+
+		assert.Len(t, rw.Header().Get(HeaderRequestID), 32, "id %q", id)
+	}
+}
+
+func TestUnaryClientInterceptorSendsRequestID(t *testing.T) {
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := WithRequestID(context.Background(), "abc-123", hclog.NewNullLogger())
+	assert.NoError(t, UnaryClientInterceptor(ctx, "/test", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"abc-123"}, md.Get(MetadataRequestID))
+
+	// no metadata is added outside of a request
+	assert.NoError(t, UnaryClientInterceptor(context.Background(), "/test", nil, nil, nil, invoker))
+	assert.Empty(t, md.Get(MetadataRequestID))
+}
@@ -0,0 +1,10 @@
+module github.com/JamieBShaw/golang-mux-rest-api/tracing
+
+go 1.14
+
+require (
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/otel v0.11.0
+	go.opentelemetry.io/otel/exporters/stdout v0.11.0
+	go.opentelemetry.io/otel/sdk v0.11.0
+)
@@ -0,0 +1,61 @@
+// Package tracing sets up OpenTelemetry tracing for the services, spans are
+// written to stdout or a file and the trace context is propagated in the
+// HTTP headers and gRPC metadata
+package tracing
+
+import (
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// Setup installs the trace provider writing the spans of the named service to
+// the output, either stdout or a file path, tracing is disabled when the
+// output is empty. The trace context is propagated even when tracing is
+// disabled. The returned function closes the output
+func Setup(serviceName, output string) (func() error, error) {
+	p := trace.DefaultHTTPPropagator()
+	global.SetPropagators(propagation.New(propagation.WithInjectors(p), propagation.WithExtractors(p)))
+
+	if output == "" {
+		return func() error { return nil }, nil
+	}
+
+	var w io.Writer = os.Stdout
+	closer := func() error { return nil }
+	if output != "stdout" {
+		f, err := os.OpenFile(output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		w, closer = f, f.Close
+	}
+
+	exp, err := stdout.NewExporter(stdout.WithWriter(w))
+	if err != nil {
+		closer()
+		return nil, err
+	}
+
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithSyncer(exp),
+		sdktrace.WithResource(resource.New(semconv.ServiceNameKey.String(serviceName))),
+	)
+	if err != nil {
+		closer()
+		return nil, err
+	}
+
+	global.SetTraceProvider(tp)
+
+	return closer, nil
+}
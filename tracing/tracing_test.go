@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/api/global"
+)
+
+func TestSetupWritesSpansToFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "spans.json")
+	closer, err := Setup("products-images", output)
+	assert.NoError(t, err)
+
+	_, span := global.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+	assert.NoError(t, closer())
+
+	d, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), "test-span")
+	assert.Contains(t, string(d), "products-images")
+}
+
+func TestSetupWithoutOutputDisablesTracing(t *testing.T) {
+	closer, err := Setup("products-images", "")
+	assert.NoError(t, err)
+	assert.NoError(t, closer())
+}